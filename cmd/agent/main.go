@@ -3,29 +3,47 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
+	_ "time/tzdata" // GCP billing months start in US/Pacific
 
 	"github.com/deepcost/kube-cost-exporter/pkg/calculator"
 	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+	"github.com/deepcost/kube-cost-exporter/pkg/config"
 	"github.com/deepcost/kube-cost-exporter/pkg/metrics"
 	"github.com/deepcost/kube-cost-exporter/pkg/pricing"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 var (
-	kubeconfig     = flag.String("kubeconfig", "", "Path to kubeconfig file (optional, uses in-cluster config by default)")
-	cloudProvider  = flag.String("cloud-provider", "aws", "Cloud provider (aws, gcp, azure)")
-	region         = flag.String("region", "us-east-1", "Cloud provider region")
-	metricsPort    = flag.String("metrics-port", "9090", "Port to expose metrics on")
-	updateInterval = flag.Duration("update-interval", 60*time.Second, "Interval to update cost metrics")
-	logger         = logrus.New()
+	kubeconfig          = flag.String("kubeconfig", "", "Path to kubeconfig file (optional, uses in-cluster config by default)")
+	configFile          = flag.String("config", "", "Path to YAML configuration file (optional)")
+	cloudProvider       = flag.String("cloud-provider", "aws", "Default cloud provider, used for nodes whose provider cannot be detected (aws, gcp, azure, oracle, digitalocean, linode, hetzner)")
+	providers           = flag.String("providers", "", "Comma-separated list of additional cloud providers to price for mixed-provider clusters")
+	region              = flag.String("region", "us-east-1", "Default cloud provider region, used when a node has no region label")
+	metricsPort         = flag.String("metrics-port", "9090", "Port to expose metrics on")
+	updateInterval      = flag.Duration("update-interval", 60*time.Second, "Interval to update cost metrics")
+	allocationMode      = flag.String("allocation-mode", calculator.AllocationRequests, "Resources to allocate node costs by: requests, usage, or max (the greater of requests and usage)")
+	overheadPolicy      = flag.String("overhead-policy", calculator.OverheadIgnore, "Who pays for node capacity reserved for the system: ignore, proportional (spread across pods), or system (charged to the __system__ namespace)")
+	cpuRAMCostRatio     = flag.Float64("cpu-ram-cost-ratio", calculator.DefaultCPURAMCostRatio, "Hourly cost of a CPU core relative to a GiB of memory, used to split node prices when the provider has no component rates")
+	idleDistribution    = flag.String("idle-distribution", calculator.IdleSeparate, "How idle node cost is reported in namespace costs: separate (as the __idle__ namespace) or proportional (spread across namespaces by their cost)")
+	daemonSetAllocation = flag.String("daemonset-allocation", calculator.DaemonSetTenant, "Who pays for DaemonSet pods: tenant (charged like other pods) or overhead (charged to the other pods on the same node)")
+	usageWindow         = flag.Duration("usage-window", 10*time.Minute, "Window to average pod usage from the metrics API over")
+	kubeletStats        = flag.Bool("kubelet-stats", false, "Collect container usage, ephemeral storage and network traffic from each node's kubelet summary API instead of the metrics API")
+	unmountedThreshold  = flag.Duration("unmounted-volume-threshold", 7*24*time.Hour, "How long a bound volume claim must go unmounted by any pod before its volume is reported as wasted")
+	costRetention       = flag.Duration("cost-retention", 24*time.Hour, "How long accumulated cost counters of deleted pods, namespaces and nodes are kept")
+	resyncPeriod        = flag.Duration("resync-period", 5*time.Minute, "Informer resync period; all nodes and volumes are repriced at this interval")
+	logger              = logrus.New()
 )
 
 func main() {
@@ -35,57 +53,103 @@ func main() {
 	logger.SetFormatter(&logrus.JSONFormatter{})
 	logger.Info("Starting Kube Cost Exporter Agent")
 
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		logger.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	if !calculator.ValidAllocationMode(*allocationMode) {
+		logger.Fatalf("Invalid allocation mode %q (use requests, usage, or max)", *allocationMode)
+	}
+	if !calculator.ValidOverheadPolicy(*overheadPolicy) {
+		logger.Fatalf("Invalid overhead policy %q (use ignore, proportional, or system)", *overheadPolicy)
+	}
+	if !calculator.ValidIdleDistribution(*idleDistribution) {
+		logger.Fatalf("Invalid idle distribution %q (use separate or proportional)", *idleDistribution)
+	}
+	if !calculator.ValidDaemonSetAllocation(*daemonSetAllocation) {
+		logger.Fatalf("Invalid DaemonSet allocation %q (use tenant or overhead)", *daemonSetAllocation)
+	}
+	if cfg.Allocation.Strategy != "" && !calculator.ValidStrategy(cfg.Allocation.Strategy) {
+		logger.Fatalf("Invalid allocation strategy %q", cfg.Allocation.Strategy)
+	}
+	for namespace, strategy := range cfg.Allocation.Namespaces {
+		if !calculator.ValidStrategy(strategy) {
+			logger.Fatalf("Invalid allocation strategy %q for namespace %s", strategy, namespace)
+		}
+	}
+
 	// Create Kubernetes client
-	config, err := getKubeConfig()
+	restConfig, err := getKubeConfig()
 	if err != nil {
 		logger.Fatalf("Failed to get Kubernetes config: %v", err)
 	}
 
-	clientset, err := kubernetes.NewForConfig(config)
+	clientset, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
 		logger.Fatalf("Failed to create Kubernetes client: %v", err)
 	}
 
-	// Initialize pricing provider
-	var pricingProvider pricing.Provider
-	switch *cloudProvider {
-	case "aws":
-		pricingProvider, err = pricing.NewAWSProvider(*region)
-		if err != nil {
-			logger.Fatalf("Failed to create AWS pricing provider: %v", err)
-		}
-	case "gcp":
-		project := os.Getenv("GCP_PROJECT")
-		if project == "" {
-			logger.Fatal("GCP_PROJECT environment variable is required for GCP provider")
-		}
-		pricingProvider, err = pricing.NewGCPProvider(project)
+	// Initialize pricing providers
+	providerRegistry := pricing.NewRegistry(*cloudProvider)
+	for _, name := range providerNames() {
+		provider, err := newPricingProvider(name)
 		if err != nil {
-			logger.Fatalf("Failed to create GCP pricing provider: %v", err)
+			logger.Fatalf("Failed to create %s pricing provider: %v", name, err)
 		}
-	case "azure":
-		subscriptionID := os.Getenv("AZURE_SUBSCRIPTION_ID")
-		if subscriptionID == "" {
-			logger.Fatal("AZURE_SUBSCRIPTION_ID environment variable is required for Azure provider")
-		}
-		pricingProvider, err = pricing.NewAzureProvider(subscriptionID)
-		if err != nil {
-			logger.Fatalf("Failed to create Azure pricing provider: %v", err)
-		}
-	default:
-		logger.Fatalf("Unknown cloud provider: %s", *cloudProvider)
+		providerRegistry.Register(name, provider)
 	}
+	logger.Infof("Pricing providers: %v (default: %s)", providerRegistry.Providers(), providerRegistry.DefaultProvider())
 
-	// Wrap provider with caching
-	pricingCache := pricing.NewPricingCache(pricingProvider)
+	// Create informers so collectors read from a local cache
+	ctx := context.Background()
+	informers, err := collector.NewInformerCache(clientset, *resyncPeriod)
+	if err != nil {
+		logger.Fatalf("Failed to create informers: %v", err)
+	}
 
 	// Initialize collectors
-	nodeCollector := collector.NewNodeCollector(clientset, pricingCache, *cloudProvider, *region)
-	podCollector := collector.NewPodCollector(clientset)
-	storageCollector := collector.NewStorageCollector(clientset, pricingCache, *cloudProvider, *region)
+	nodeCollector := collector.NewNodeCollector(informers, providerRegistry, *region, cfg.RootVolumes)
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		logger.Fatalf("Failed to create dynamic Kubernetes client: %v", err)
+	}
+	owners := collector.NewOwnerResolver(informers, dynamicClient, clientset.Discovery())
+
+	podCollector := collector.NewPodCollector(informers, owners)
+	namespaceCollector := collector.NewNamespaceCollector(informers)
+	storageCollector := collector.NewStorageCollector(informers, owners, providerRegistry, *region)
+	usageCollector := collector.NewUsageCollector(clientset, *usageWindow)
+	var kubeletCollector *collector.KubeletCollector
+	if *kubeletStats {
+		kubeletCollector = collector.NewKubeletCollector(clientset, informers, usageCollector)
+	}
+	lifecycleTracker, err := collector.NewPodLifecycleTracker(informers, podCollector)
+	if err != nil {
+		logger.Fatalf("Failed to create pod lifecycle tracker: %v", err)
+	}
+
+	// Start informers once all event handlers are registered
+	if err := informers.Start(ctx); err != nil {
+		logger.Fatalf("Failed to start informers: %v", err)
+	}
 
 	// Initialize calculator and metrics exporter
-	calc := calculator.NewCostCalculator()
+	calc := calculator.NewCostCalculator(calculator.Options{
+		AllocationMode:      *allocationMode,
+		OverheadPolicy:      *overheadPolicy,
+		IdleDistribution:    *idleDistribution,
+		DaemonSetAllocation: *daemonSetAllocation,
+		CPURAMCostRatio:     *cpuRAMCostRatio,
+
+		Strategy:            cfg.Allocation.Strategy,
+		NamespaceStrategies: cfg.Allocation.Namespaces,
+		CPUWeight:           cfg.Allocation.Weights.CPU,
+		MemoryWeight:        cfg.Allocation.Weights.Memory,
+	})
+	accumulator := calculator.NewCostAccumulator(*costRetention)
+	sustainedUse := calculator.NewSustainedUseTracker()
+	wasteDetector := calculator.NewWasteDetector(*unmountedThreshold)
 	exporter := metrics.NewExporter()
 	storageMetrics := metrics.NewStorageMetrics()
 
@@ -113,25 +177,32 @@ func main() {
 	}()
 
 	// Start cost collection loop
-	ctx := context.Background()
 	ticker := time.NewTicker(*updateInterval)
 	defer ticker.Stop()
 
 	// Run immediately on startup
-	collectAndExportMetrics(ctx, nodeCollector, podCollector, storageCollector, calc, exporter, storageMetrics)
+	collectAndExportMetrics(ctx, cfg, nodeCollector, podCollector, namespaceCollector, storageCollector, usageCollector, kubeletCollector, lifecycleTracker, calc, accumulator, sustainedUse, wasteDetector, exporter, storageMetrics)
 
 	// Then run on schedule
 	for range ticker.C {
-		collectAndExportMetrics(ctx, nodeCollector, podCollector, storageCollector, calc, exporter, storageMetrics)
+		collectAndExportMetrics(ctx, cfg, nodeCollector, podCollector, namespaceCollector, storageCollector, usageCollector, kubeletCollector, lifecycleTracker, calc, accumulator, sustainedUse, wasteDetector, exporter, storageMetrics)
 	}
 }
 
 func collectAndExportMetrics(
 	ctx context.Context,
+	cfg *config.Config,
 	nodeCollector *collector.NodeCollector,
 	podCollector *collector.PodCollector,
+	namespaceCollector *collector.NamespaceCollector,
 	storageCollector *collector.StorageCollector,
+	usageCollector *collector.UsageCollector,
+	kubeletCollector *collector.KubeletCollector,
+	lifecycleTracker *collector.PodLifecycleTracker,
 	calc *calculator.CostCalculator,
+	accumulator *calculator.CostAccumulator,
+	sustainedUse *calculator.SustainedUseTracker,
+	wasteDetector *calculator.WasteDetector,
 	exporter *metrics.Exporter,
 	storageMetrics *metrics.StorageMetrics,
 ) {
@@ -145,6 +216,9 @@ func collectAndExportMetrics(
 	}
 	logger.Infof("Collected %d nodes", len(nodes))
 
+	// Apply provider discounts to get effective node rates
+	nodes, discounts := calc.ApplyGCPDiscounts(nodes, cfg.GCP, sustainedUse, time.Now())
+
 	// Collect pods
 	pods, err := podCollector.CollectPods(ctx)
 	if err != nil {
@@ -153,36 +227,67 @@ func collectAndExportMetrics(
 	}
 	logger.Infof("Collected %d pods", len(pods))
 
-	// Calculate pod costs
-	nodeMap := make(map[string]collector.NodeInfo)
-	for _, node := range nodes {
-		nodeMap[node.Name] = node
-	}
-
-	var podCosts []calculator.PodCost
-	for _, pod := range pods {
-		node, exists := nodeMap[pod.NodeName]
-		if !exists {
-			logger.Warnf("Node %s not found for pod %s/%s", pod.NodeName, pod.Namespace, pod.Name)
-			continue
+	// Collect usage from the kubelets or the metrics API; pods without usage are
+	// allocated by requests
+	if kubeletCollector != nil {
+		if err := kubeletCollector.CollectStats(ctx); err != nil {
+			logger.Warnf("Failed to collect kubelet stats: %v", err)
 		}
+		kubeletCollector.ApplyStats(pods)
+	} else if err := usageCollector.CollectUsage(ctx); err != nil {
+		logger.Warnf("Failed to collect pod usage: %v", err)
+	}
+	usageCollector.ApplyUsage(pods)
 
-		podCost, err := calc.CalculatePodCost(pod, node)
+	// Calculate pod costs
+	podCosts := calc.CalculatePodCosts(pods, nodes)
+	overheadCosts := calc.CalculateOverheadCosts(nodes)
+
+	// DaemonSet fleet costs are reported before DaemonSet pods are charged as overhead
+	daemonSetCosts := calc.CalculateDaemonSetCosts(podCosts)
+	podCosts = calc.RedistributeDaemonSetCosts(podCosts)
+
+	// Idle cost is what pods and overhead are not charged of each node, so it is
+	// calculated before sidecar costs move out of pods
+	nodeIdleCosts := calc.CalculateIdleCosts(nodes, podCosts, overheadCosts)
+	nodePoolIdleCosts := calc.CalculateNodePoolIdleCosts(nodeIdleCosts)
+	clusterIdleCost := calc.CalculateClusterIdleCost(nodeIdleCosts)
+
+	// Charge sidecar containers to the teams that own them
+	podCosts, sidecarCosts := calc.CarveOutSidecarCosts(podCosts, cfg.Sidecars)
+
+	// Price pending pods on the cheapest node shape that fits them
+	pendingCosts := calc.CalculatePendingPodCosts(pods, nodes, time.Now())
+	namespacePendingCosts := calc.CalculateNamespacePendingCosts(pendingCosts)
+
+	// Calculate node pool costs, then namespace costs before and after shared cost
+	// rules, and workload costs
+	nodePoolCosts := calc.CalculateNodePoolCosts(nodes, podCosts, nodeIdleCosts)
+	directNamespaceCosts := calc.AddOverheadNamespaceCosts(calc.CalculateNamespaceCosts(podCosts), overheadCosts)
+	directNamespaceCosts = calc.AddSidecarNamespaceCosts(directNamespaceCosts, sidecarCosts)
+	directNamespaceCosts = calc.DistributeIdleCost(directNamespaceCosts, clusterIdleCost)
+	namespaceCosts, sharedCosts := calc.DistributeSharedCosts(directNamespaceCosts, podCosts, cfg.SharedCosts)
+	workloadCosts := calc.CalculateWorkloadCosts(podCosts)
+
+	// Aggregate pod costs by the configured dimensions
+	var dimensionCosts []calculator.DimensionCost
+	if len(cfg.Dimensions) > 0 {
+		namespaces, err := namespaceCollector.CollectNamespaces()
 		if err != nil {
-			logger.Warnf("Failed to calculate cost for pod %s/%s: %v", pod.Namespace, pod.Name, err)
-			continue
+			logger.Warnf("Failed to collect namespaces: %v", err)
 		}
-
-		podCosts = append(podCosts, podCost)
+		dimensionCosts = calc.CalculateDimensionCosts(podCosts, namespaces, cfg.Dimensions)
 	}
 
-	// Calculate namespace costs
-	namespaceCosts := calc.CalculateNamespaceCosts(podCosts)
-
 	// Calculate cluster metrics
+	unitRates := make(map[string]calculator.UnitRates)
+	for _, node := range nodes {
+		unitRates[node.Name] = calc.CalculateUnitRates(node)
+	}
 	totalCost := calc.CalculateTotalClusterCost(nodes)
 	detailedSpotSavings := calc.CalculateDetailedSpotSavings(nodes)
 	namespaceSpotUsage := calc.CalculateNamespaceSpotUsage(podCosts, nodes)
+	arm64Equivalents := calc.CalculateArm64Equivalents(podCosts, nodes)
 
 	// Collect storage (PVs)
 	pvs, err := storageCollector.CollectPVs(ctx)
@@ -198,6 +303,11 @@ func collectAndExportMetrics(
 			storageCosts = append(storageCosts, cost)
 		}
 
+		// Attribute volume costs to the pods that mount them and their workloads
+		var storageAllocations []calculator.StorageAllocation
+		podCosts, storageAllocations = calc.AllocateStorageCosts(podCosts, storageCosts)
+		workloadCosts = calc.AddWorkloadStorageCosts(workloadCosts, storageAllocations)
+
 		// Calculate namespace storage costs
 		namespaceStorageCosts := calc.CalculateNamespaceStorageCosts(storageCosts)
 		totalStorageCost := calc.CalculateTotalStorageCost(pvs)
@@ -207,21 +317,98 @@ func collectAndExportMetrics(
 		storageMetrics.UpdateNamespaceStorageMetrics(namespaceStorageCosts)
 		storageMetrics.UpdateClusterStorageMetrics(totalStorageCost)
 
+		// Detect volumes that are paid for but not used
+		wastedVolumes := wasteDetector.Detect(time.Now(), pvs, pods)
+		storageMetrics.UpdateWasteMetrics(wastedVolumes)
+
 		logger.Infof("Storage metrics updated. Total monthly storage cost: $%.2f", totalStorageCost)
 	}
 
 	// Update Prometheus metrics
 	exporter.UpdatePodMetrics(podCosts)
 	exporter.UpdateNamespaceMetrics(namespaceCosts)
+	exporter.UpdateSharedCostMetrics(directNamespaceCosts, sharedCosts)
+	exporter.UpdateWorkloadMetrics(workloadCosts)
+	exporter.UpdateDaemonSetMetrics(daemonSetCosts)
+	exporter.UpdateSidecarMetrics(sidecarCosts)
+	exporter.UpdateDimensionMetrics(dimensionCosts)
 	exporter.UpdateNodeMetrics(nodes)
+	exporter.UpdateDiscountMetrics(nodes, discounts)
 	exporter.UpdateClusterMetrics(totalCost, detailedSpotSavings.TotalSavingsHourly)
 	exporter.UpdateDetailedSpotMetrics(detailedSpotSavings)
 	exporter.UpdateNamespaceSpotMetrics(namespaceSpotUsage)
+	exporter.UpdateArm64Metrics(nodes, arm64Equivalents)
+	exporter.UpdateIdleMetrics(nodeIdleCosts, nodePoolIdleCosts, clusterIdleCost)
+	exporter.UpdateNodePoolMetrics(nodePoolCosts)
+	exporter.UpdateUnitRateMetrics(unitRates)
+	exporter.UpdatePendingMetrics(pendingCosts, namespacePendingCosts)
+
+	// Accumulate costs over the time since the previous cycle, including pods that
+	// finished since it
+	finishedRuns := lifecycleTracker.TakeFinished(ctx)
+	finishedCosts := calc.CalculateFinishedPodCosts(finishedRuns, nodes, podCosts)
+	accumulator.Accumulate(time.Now(), podCosts, finishedCosts, namespaceCosts, nodes)
+	exporter.UpdateAccumulatedMetrics(accumulator)
 
 	logger.Infof("Metrics updated successfully. Cluster hourly cost: $%.2f, spot savings: $%.2f/hr",
 		totalCost, detailedSpotSavings.TotalSavingsHourly)
 }
 
+// providerNames returns the default provider followed by any additional providers
+func providerNames() []string {
+	names := []string{*cloudProvider}
+	seen := map[string]bool{*cloudProvider: true}
+
+	for _, name := range strings.Split(*providers, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// newPricingProvider creates the pricing provider for a cloud provider name
+func newPricingProvider(name string) (pricing.Provider, error) {
+	switch name {
+	case "aws":
+		return pricing.NewAWSProvider(*region)
+	case "gcp":
+		project := os.Getenv("GCP_PROJECT")
+		if project == "" {
+			return nil, fmt.Errorf("GCP_PROJECT environment variable is required for GCP provider")
+		}
+		return pricing.NewGCPProvider(project)
+	case "azure":
+		subscriptionID := os.Getenv("AZURE_SUBSCRIPTION_ID")
+		if subscriptionID == "" {
+			return nil, fmt.Errorf("AZURE_SUBSCRIPTION_ID environment variable is required for Azure provider")
+		}
+		return pricing.NewAzureProvider(subscriptionID)
+	case "oracle":
+		return pricing.NewOracleProvider(os.Getenv("ORACLE_PRICE_LIST"))
+	case "digitalocean":
+		return pricing.NewDigitalOceanProvider(os.Getenv("DIGITALOCEAN_PRICE_LIST"))
+	case "linode":
+		return pricing.NewLinodeProvider(os.Getenv("LINODE_PRICE_LIST"))
+	case "hetzner":
+		var eurToUSD float64
+		if rate := os.Getenv("HETZNER_EUR_USD_RATE"); rate != "" {
+			var err error
+			eurToUSD, err = strconv.ParseFloat(rate, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid HETZNER_EUR_USD_RATE: %w", err)
+			}
+		}
+		return pricing.NewHetznerProvider(os.Getenv("HETZNER_PRICE_LIST"), eurToUSD)
+	default:
+		return nil, fmt.Errorf("unknown cloud provider: %s", name)
+	}
+}
+
 func getKubeConfig() (*rest.Config, error) {
 	if *kubeconfig != "" {
 		// Use kubeconfig file
@@ -78,6 +78,12 @@ func main() {
 			os.Exit(1)
 		}
 
+	case "nodepool":
+		if err := showNodePoolCost(ctx, v1api); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
 	case "cluster":
 		if err := showClusterCost(ctx, v1api); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -94,6 +100,12 @@ func main() {
 			os.Exit(1)
 		}
 
+	case "arm":
+		if err := showArm64Savings(ctx, v1api); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
 	case "estimate":
 		var filename string
 		for i, arg := range flag.Args() {
@@ -127,8 +139,10 @@ func printUsage() {
 	fmt.Println("  kubectl cost namespace <name|--all> [--window <duration>]")
 	fmt.Println("  kubectl cost pod <name> [--namespace <namespace>] [--window <duration>]")
 	fmt.Println("  kubectl cost node [--window <duration>]")
+	fmt.Println("  kubectl cost nodepool [--window <duration>]")
 	fmt.Println("  kubectl cost cluster [--window <duration>]")
-	fmt.Println("  kubectl cost top <pods|namespaces|nodes> [--window <duration>]")
+	fmt.Println("  kubectl cost top <pods|namespaces|nodes|workloads> [--window <duration>]")
+	fmt.Println("  kubectl cost arm [--window <duration>]")
 	fmt.Println("  kubectl cost estimate -f <manifest-file>")
 	fmt.Println()
 	fmt.Println("Options:")
@@ -140,16 +154,20 @@ func printUsage() {
 	fmt.Println("  kubectl cost namespace production --window 30d")
 	fmt.Println("  kubectl cost pod my-pod --namespace default")
 	fmt.Println("  kubectl cost cluster")
+	fmt.Println("  kubectl cost nodepool --window 7d")
 	fmt.Println("  kubectl cost top namespaces")
+	fmt.Println("  kubectl cost arm --window 7d")
 	fmt.Println("  kubectl cost estimate -f deployment.yaml")
 }
 
 func showNamespaceCost(ctx context.Context, api v1.API, namespace string) error {
-	var query string
+	var query, totalQuery string
 	if namespace == "--all" {
 		query = fmt.Sprintf(`sum(avg_over_time(kube_cost_namespace_hourly_usd[%s])) by (namespace)`, *window)
+		totalQuery = fmt.Sprintf(`sum(increase(kube_cost_namespace_cost_usd_total[%s])) by (namespace)`, *window)
 	} else {
-		query = fmt.Sprintf(`sum(avg_over_time(kube_cost_namespace_hourly_usd{namespace="%s"}[%s]))`, namespace, *window)
+		query = fmt.Sprintf(`sum(avg_over_time(kube_cost_namespace_hourly_usd{namespace="%s"}[%s])) by (namespace)`, namespace, *window)
+		totalQuery = fmt.Sprintf(`sum(increase(kube_cost_namespace_cost_usd_total{namespace="%s"}[%s])) by (namespace)`, namespace, *window)
 	}
 
 	result, _, err := api.Query(ctx, query, time.Now())
@@ -168,7 +186,7 @@ func showNamespaceCost(ctx context.Context, api v1.API, namespace string) error
 	}
 
 	duration := parseDuration(*window)
-	hourlyMultiplier := duration / time.Hour
+	totals := queryTotals(ctx, api, totalQuery, func(m model.Metric) string { return string(m["namespace"]) })
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
 	fmt.Fprintln(w, "NAMESPACE\tHOURLY COST\tTOTAL COST\tMONTHLY PROJECTION")
@@ -176,7 +194,7 @@ func showNamespaceCost(ctx context.Context, api v1.API, namespace string) error
 	for _, sample := range vector {
 		ns := string(sample.Metric["namespace"])
 		hourlyCost := float64(sample.Value)
-		totalCost := hourlyCost * float64(hourlyMultiplier)
+		totalCost := windowCost(totals, ns, hourlyCost, duration)
 		monthlyCost := hourlyCost * 730
 
 		fmt.Fprintf(w, "%s\t$%.4f\t$%.2f\t$%.2f\n", ns, hourlyCost, totalCost, monthlyCost)
@@ -188,6 +206,7 @@ func showNamespaceCost(ctx context.Context, api v1.API, namespace string) error
 
 func showPodCost(ctx context.Context, api v1.API, namespace, podName string) error {
 	query := fmt.Sprintf(`avg_over_time(kube_cost_pod_hourly_usd{namespace="%s",pod=~"%s.*"}[%s])`, namespace, podName, *window)
+	totalQuery := fmt.Sprintf(`increase(kube_cost_pod_cost_usd_total{namespace="%s",pod=~"%s.*"}[%s])`, namespace, podName, *window)
 
 	result, _, err := api.Query(ctx, query, time.Now())
 	if err != nil {
@@ -205,7 +224,7 @@ func showPodCost(ctx context.Context, api v1.API, namespace, podName string) err
 	}
 
 	duration := parseDuration(*window)
-	hourlyMultiplier := duration / time.Hour
+	totals := queryTotals(ctx, api, totalQuery, podKey)
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
 	fmt.Fprintln(w, "POD\tNAMESPACE\tNODE\tHOURLY COST\tTOTAL COST\tMONTHLY PROJECTION")
@@ -215,7 +234,7 @@ func showPodCost(ctx context.Context, api v1.API, namespace, podName string) err
 		ns := string(sample.Metric["namespace"])
 		node := string(sample.Metric["node"])
 		hourlyCost := float64(sample.Value)
-		totalCost := hourlyCost * float64(hourlyMultiplier)
+		totalCost := windowCost(totals, podKey(sample.Metric), hourlyCost, duration)
 		monthlyCost := hourlyCost * 730
 
 		fmt.Fprintf(w, "%s\t%s\t%s\t$%.4f\t$%.2f\t$%.2f\n", pod, ns, node, hourlyCost, totalCost, monthlyCost)
@@ -227,6 +246,7 @@ func showPodCost(ctx context.Context, api v1.API, namespace, podName string) err
 
 func showNodeCost(ctx context.Context, api v1.API) error {
 	query := fmt.Sprintf(`avg_over_time(kube_cost_node_hourly_usd[%s])`, *window)
+	totalQuery := fmt.Sprintf(`increase(kube_cost_node_cost_usd_total[%s])`, *window)
 
 	result, _, err := api.Query(ctx, query, time.Now())
 	if err != nil {
@@ -244,20 +264,71 @@ func showNodeCost(ctx context.Context, api v1.API) error {
 	}
 
 	duration := parseDuration(*window)
-	hourlyMultiplier := duration / time.Hour
+	totals := queryTotals(ctx, api, totalQuery, func(m model.Metric) string { return string(m["node"]) })
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "NODE\tINSTANCE TYPE\tSPOT\tHOURLY COST\tTOTAL COST\tMONTHLY PROJECTION")
+	fmt.Fprintln(w, "NODE\tINSTANCE TYPE\tARCH\tSPOT\tHOURLY COST\tTOTAL COST\tMONTHLY PROJECTION")
 
 	for _, sample := range vector {
 		node := string(sample.Metric["node"])
 		instanceType := string(sample.Metric["instance_type"])
+		arch := string(sample.Metric["arch"])
 		isSpot := string(sample.Metric["is_spot"])
 		hourlyCost := float64(sample.Value)
-		totalCost := hourlyCost * float64(hourlyMultiplier)
+		totalCost := windowCost(totals, node, hourlyCost, duration)
 		monthlyCost := hourlyCost * 730
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t$%.4f\t$%.2f\t$%.2f\n", node, instanceType, isSpot, hourlyCost, totalCost, monthlyCost)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t$%.4f\t$%.2f\t$%.2f\n", node, instanceType, arch, isSpot, hourlyCost, totalCost, monthlyCost)
+	}
+
+	w.Flush()
+	return nil
+}
+
+func showNodePoolCost(ctx context.Context, api v1.API) error {
+	query := fmt.Sprintf(`avg_over_time(kube_cost_nodepool_hourly_usd[%s])`, *window)
+
+	vector, err := queryVector(ctx, api, query)
+	if err != nil {
+		return err
+	}
+
+	if len(vector) == 0 {
+		fmt.Println("No node pool cost data found")
+		return nil
+	}
+
+	poolKey := func(m model.Metric) string { return string(m["node_pool"]) }
+	resourceKey := func(m model.Metric) string { return fmt.Sprintf("%s/%s", m["node_pool"], m["resource"]) }
+	idle := queryTotals(ctx, api, fmt.Sprintf(`sum(avg_over_time(kube_cost_nodepool_idle_hourly_usd[%s])) by (node_pool)`, *window), poolKey)
+	nodes := queryTotals(ctx, api, `sum(kube_cost_nodepool_nodes) by (node_pool)`, poolKey)
+	spot := queryTotals(ctx, api, `kube_cost_nodepool_spot_ratio`, poolKey)
+	capacity := queryTotals(ctx, api, `kube_cost_nodepool_capacity`, resourceKey)
+	allocated := queryTotals(ctx, api, `kube_cost_nodepool_allocated`, resourceKey)
+
+	// percent returns the allocated share of a pool's capacity of a resource
+	percent := func(pool, resource string) float64 {
+		key := pool + "/" + resource
+		if capacity[key] <= 0 {
+			return 0
+		}
+		return allocated[key] / capacity[key] * 100
+	}
+
+	duration := parseDuration(*window)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NODE POOL\tNODES\tSPOT\tCPU ALLOCATED\tMEMORY ALLOCATED\tHOURLY COST\tIDLE COST\tTOTAL COST\tMONTHLY PROJECTION")
+
+	for _, sample := range vector {
+		pool := poolKey(sample.Metric)
+		hourlyCost := float64(sample.Value)
+		totalCost := hourlyCost * duration.Hours()
+		monthlyCost := hourlyCost * 730
+
+		fmt.Fprintf(w, "%s\t%.0f\t%.0f%%\t%.0f%%\t%.0f%%\t$%.4f\t$%.4f\t$%.2f\t$%.2f\n",
+			pool, nodes[pool], spot[pool]*100, percent(pool, "cpu"), percent(pool, "memory"),
+			hourlyCost, idle[pool], totalCost, monthlyCost)
 	}
 
 	w.Flush()
@@ -272,13 +343,12 @@ func showClusterCost(ctx context.Context, api v1.API) error {
 	}
 
 	duration := parseDuration(*window)
-	hourlyMultiplier := duration / time.Hour
 
 	fmt.Println("Cluster Cost Summary")
 	fmt.Println("====================")
 	fmt.Println()
 
-	var totalHourly, totalMonthly float64
+	var totalHourly, totalMonthly, storageHourly float64
 
 	for name, query := range queries {
 		result, _, err := api.Query(ctx, query, time.Now())
@@ -301,6 +371,7 @@ func showClusterCost(ctx context.Context, api v1.API) error {
 			fmt.Printf("%-15s: $%.2f/hour  |  $%.2f/month\n", name, hourlyValue, monthlyValue)
 			totalHourly += hourlyValue
 			totalMonthly += monthlyValue
+			storageHourly = hourlyValue
 		} else if name == "Spot Savings" {
 			monthlyValue := value * 730
 			fmt.Printf("%-15s: $%.2f/hour  |  $%.2f/month (savings)\n", name, value, monthlyValue)
@@ -314,7 +385,14 @@ func showClusterCost(ctx context.Context, api v1.API) error {
 
 	fmt.Println()
 	fmt.Printf("Total Cost      : $%.2f/hour  |  $%.2f/month\n", totalHourly, totalMonthly)
-	fmt.Printf("Window (%s)    : $%.2f\n", *window, totalHourly*float64(hourlyMultiplier))
+
+	// Use accumulated node costs for compute when the agent exports them
+	windowTotal := totalHourly * duration.Hours()
+	nodeTotals, err := queryVector(ctx, api, fmt.Sprintf(`sum(increase(kube_cost_node_cost_usd_total[%s]))`, *window))
+	if err == nil && len(nodeTotals) > 0 {
+		windowTotal = float64(nodeTotals[0].Value) + storageHourly*duration.Hours()
+	}
+	fmt.Printf("Window (%s)    : $%.2f\n", *window, windowTotal)
 
 	return nil
 }
@@ -333,8 +411,11 @@ func showTopCosts(ctx context.Context, api v1.API, resource string) error {
 	case "nodes":
 		query = fmt.Sprintf(`topk(10, avg_over_time(kube_cost_node_hourly_usd[%s]))`, *window)
 		labelName = "node"
+	case "workloads":
+		query = fmt.Sprintf(`topk(10, avg_over_time(kube_cost_workload_hourly_usd[%s]))`, *window)
+		labelName = "name"
 	default:
-		return fmt.Errorf("unknown resource type: %s (use: pods, namespaces, or nodes)", resource)
+		return fmt.Errorf("unknown resource type: %s (use: pods, namespaces, nodes, or workloads)", resource)
 	}
 
 	result, _, err := api.Query(ctx, query, time.Now())
@@ -375,6 +456,17 @@ func showTopCosts(ctx context.Context, api v1.API, resource string) error {
 
 			fmt.Fprintf(w, "%d\t%s\t%s\t$%.4f\t$%.2f\n", i+1, pod, namespace, hourlyCost, monthlyCost)
 		}
+	} else if resource == "workloads" {
+		fmt.Fprintln(w, "RANK\tKIND\tNAME\tNAMESPACE\tHOURLY COST\tMONTHLY PROJECTION")
+		for i, sample := range vector {
+			kind := string(sample.Metric["kind"])
+			name := string(sample.Metric["name"])
+			namespace := string(sample.Metric["namespace"])
+			hourlyCost := float64(sample.Value)
+			monthlyCost := hourlyCost * 730
+
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t$%.4f\t$%.2f\n", i+1, kind, name, namespace, hourlyCost, monthlyCost)
+		}
 	} else {
 		fmt.Fprintln(w, "RANK\tNAME\tHOURLY COST\tMONTHLY PROJECTION")
 		for i, sample := range vector {
@@ -390,6 +482,111 @@ func showTopCosts(ctx context.Context, api v1.API, resource string) error {
 	return nil
 }
 
+func showArm64Savings(ctx context.Context, api v1.API) error {
+	savingsQuery := fmt.Sprintf(`avg_over_time(kube_cost_workload_arm64_savings_hourly_usd[%s])`, *window)
+	arm64Query := fmt.Sprintf(`avg_over_time(kube_cost_workload_arm64_equivalent_hourly_usd[%s])`, *window)
+
+	savings, err := queryVector(ctx, api, savingsQuery)
+	if err != nil {
+		return err
+	}
+	if len(savings) == 0 {
+		fmt.Println("No arm64 equivalent data found (no amd64 workloads with an arm64 equivalent)")
+		return nil
+	}
+
+	arm64, err := queryVector(ctx, api, arm64Query)
+	if err != nil {
+		return err
+	}
+	arm64Costs := make(map[string]float64)
+	for _, sample := range arm64 {
+		arm64Costs[workloadKey(sample.Metric)] = float64(sample.Value)
+	}
+
+	// Sort by savings descending
+	sort.Slice(savings, func(i, j int) bool {
+		return savings[i].Value > savings[j].Value
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tKIND\tNAME\tCURRENT HOURLY\tARM64 HOURLY\tSAVINGS\tMONTHLY SAVINGS")
+
+	var totalCurrent, totalArm64 float64
+	for _, sample := range savings {
+		arm64Cost := arm64Costs[workloadKey(sample.Metric)]
+		currentCost := arm64Cost + float64(sample.Value)
+		totalCurrent += currentCost
+		totalArm64 += arm64Cost
+
+		savingsPercent := 0.0
+		if currentCost > 0 {
+			savingsPercent = float64(sample.Value) / currentCost * 100
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t$%.4f\t$%.4f\t%.1f%%\t$%.2f\n",
+			sample.Metric["namespace"], sample.Metric["owner_kind"], sample.Metric["owner_name"],
+			currentCost, arm64Cost, savingsPercent, float64(sample.Value)*730)
+	}
+
+	fmt.Fprintln(w, "")
+	fmt.Fprintf(w, "TOTAL\t\t\t$%.4f\t$%.4f\t\t$%.2f\n", totalCurrent, totalArm64, (totalCurrent-totalArm64)*730)
+	w.Flush()
+
+	fmt.Println()
+	fmt.Println("Note: Estimates assume each workload's images are available for arm64 and")
+	fmt.Println("perform the same on the equivalent arm64 instance family.")
+
+	return nil
+}
+
+// queryVector runs an instant query and returns the result as a vector
+func queryVector(ctx context.Context, api v1.API, query string) (model.Vector, error) {
+	result, _, err := api.Query(ctx, query, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error querying Prometheus: %w", err)
+	}
+
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type: %T", result)
+	}
+	return vector, nil
+}
+
+// queryTotals runs a query over accumulated cost counters and returns the results
+// by key. Errors return no totals, e.g. for agents that do not export counters.
+func queryTotals(ctx context.Context, api v1.API, query string, key func(model.Metric) string) map[string]float64 {
+	totals := make(map[string]float64)
+	vector, err := queryVector(ctx, api, query)
+	if err != nil {
+		return totals
+	}
+	for _, sample := range vector {
+		totals[key(sample.Metric)] += float64(sample.Value)
+	}
+	return totals
+}
+
+// windowCost returns the accumulated cost over the window, falling back to the
+// average hourly cost times the window length
+func windowCost(totals map[string]float64, key string, hourlyCost float64, window time.Duration) float64 {
+	if total, ok := totals[key]; ok {
+		return total
+	}
+	return hourlyCost * window.Hours()
+}
+
+// podKey identifies a pod from its namespace, name and node labels
+func podKey(metric model.Metric) string {
+	return fmt.Sprintf("%s/%s/%s", metric["namespace"], metric["pod"], metric["node"])
+}
+
+// workloadKey identifies a workload from its namespace and owner labels
+func workloadKey(metric model.Metric) string {
+	return fmt.Sprintf("%s/%s/%s", metric["namespace"], metric["owner_kind"], metric["owner_name"])
+}
+
 func parseDuration(d string) time.Duration {
 	duration, err := time.ParseDuration(d)
 	if err != nil {
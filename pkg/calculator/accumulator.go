@@ -0,0 +1,285 @@
+package calculator
+
+import (
+	"sort"
+	"time"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+)
+
+// AccumulatedCost is the total cost of a pod, namespace or node since the agent
+// started tracking it
+type AccumulatedCost struct {
+	Namespace string // pods and namespaces
+	Pod       string // pods
+	Node      string // pods and nodes
+	TotalCost float64
+	LastSeen  time.Time
+}
+
+// FinishedPodCost is the hourly cost of a pod that finished or was deleted, and the
+// time it ran
+type FinishedPodCost struct {
+	PodCost
+	Start time.Time
+	End   time.Time
+}
+
+// accumulatedEntry is an accumulated cost and the hourly rate it accrues at until
+// the next collection cycle
+type accumulatedEntry struct {
+	AccumulatedCost
+	hourlyRate float64
+}
+
+// CostAccumulator integrates hourly cost rates over the real time between
+// collection cycles. Each cycle's rate is charged until the next cycle. Pods are
+// charged from when they started if that was after the previous cycle, and until
+// they finished if that was before the current one, so pods that start and finish
+// between cycles are charged for their exact runtime. Totals only ever increase.
+// Costs of pods, namespaces and nodes that are gone are kept for the retention
+// period. The runs of a pod recreated with the same name on the same node are
+// summed, including runs past the retention period while later runs are kept.
+type CostAccumulator struct {
+	retention   time.Duration
+	lastUpdate  time.Time
+	pods        map[string]*accumulatedEntry // by pod UID
+	namespaces  map[string]*accumulatedEntry
+	nodes       map[string]*accumulatedEntry
+	expiredRuns map[string]float64 // total cost of expired pod runs by namespace, name and node
+}
+
+// NewCostAccumulator creates a new cost accumulator
+func NewCostAccumulator(retention time.Duration) *CostAccumulator {
+	return &CostAccumulator{
+		retention:   retention,
+		pods:        make(map[string]*accumulatedEntry),
+		namespaces:  make(map[string]*accumulatedEntry),
+		nodes:       make(map[string]*accumulatedEntry),
+		expiredRuns: make(map[string]float64),
+	}
+}
+
+// Accumulate charges the rates of the previous cycle for the time since it and
+// the runs of pods that finished since it, then records the current rates
+func (ca *CostAccumulator) Accumulate(now time.Time, podCosts []PodCost, finished []FinishedPodCost, namespaceCosts []NamespaceCost, nodes []collector.NodeInfo) {
+	previous := ca.lastUpdate
+	ca.lastUpdate = now
+
+	// Pods seen in the previous cycle that finished since are charged their previous
+	// rate until they finished, and their rate is taken out of their namespace's
+	// rate for the rest of the cycle. Runs before the first cycle are not tracked.
+	runEnds := make(map[string]time.Time)     // by pod key
+	finishedRates := make(map[string]float64) // by namespace
+	if !previous.IsZero() {
+		for _, run := range finished {
+			key := podAccumulatorKey(run.PodCost)
+			entry, ok := ca.pods[key]
+			if !ok || entry.hourlyRate <= 0 {
+				ca.chargeRun(run, previous, now)
+				continue
+			}
+
+			end := run.End
+			if end.Before(previous) {
+				end = previous
+			}
+			if end.After(now) {
+				end = now
+			}
+			runEnds[key] = end
+			finishedRates[run.Namespace] += entry.hourlyRate
+			ca.charge(ca.namespaces, run.Namespace, AccumulatedCost{Namespace: run.Namespace}, entry.hourlyRate*end.Sub(previous).Hours(), now)
+		}
+	}
+
+	var hours float64
+	if !previous.IsZero() {
+		hours = now.Sub(previous).Hours()
+	}
+	for key, entry := range ca.pods {
+		podHours := hours
+		if end, ok := runEnds[key]; ok {
+			podHours = end.Sub(previous).Hours()
+		}
+		entry.TotalCost += entry.hourlyRate * podHours
+	}
+	for key, entry := range ca.namespaces {
+		entry.TotalCost += max(entry.hourlyRate-finishedRates[key], 0) * hours
+	}
+	for _, entry := range ca.nodes {
+		entry.TotalCost += entry.hourlyRate * hours
+	}
+	for _, cost := range ca.expire(ca.pods, now) {
+		ca.expiredRuns[podSeriesKey(cost)] += cost.TotalCost
+	}
+	ca.expire(ca.namespaces, now)
+	ca.expire(ca.nodes, now)
+
+	for _, pod := range podCosts {
+		key := podAccumulatorKey(pod)
+
+		// Pods that started since the previous cycle are charged from their start
+		if _, ok := ca.pods[key]; !ok && !previous.IsZero() && pod.StartTime.After(previous) && now.After(pod.StartTime) {
+			charge := pod.HourlyCost * now.Sub(pod.StartTime).Hours()
+			ca.charge(ca.pods, key, podAccumulatedCost(pod), charge, now)
+			ca.charge(ca.namespaces, pod.Namespace, AccumulatedCost{Namespace: pod.Namespace}, charge, now)
+		}
+
+		ca.record(ca.pods, key, podAccumulatedCost(pod), pod.HourlyCost, now)
+	}
+	for _, ns := range namespaceCosts {
+		ca.record(ca.namespaces, ns.Namespace, AccumulatedCost{Namespace: ns.Namespace}, ns.HourlyCost, now)
+	}
+	for _, node := range nodes {
+		ca.record(ca.nodes, node.Name, AccumulatedCost{Node: node.Name}, node.TotalHourlyCost(), now)
+	}
+
+	// Expired runs are only carried while a later run of the pod is kept
+	live := make(map[string]bool)
+	for _, entry := range ca.pods {
+		live[podSeriesKey(entry.AccumulatedCost)] = true
+	}
+	for key := range ca.expiredRuns {
+		if !live[key] {
+			delete(ca.expiredRuns, key)
+		}
+	}
+}
+
+// expire resets the hourly rates of entries and removes the entries not seen for
+// the retention period, returning their costs
+func (ca *CostAccumulator) expire(entries map[string]*accumulatedEntry, now time.Time) []AccumulatedCost {
+	var expired []AccumulatedCost
+	for key, entry := range entries {
+		entry.hourlyRate = 0
+		if now.Sub(entry.LastSeen) > ca.retention {
+			expired = append(expired, entry.AccumulatedCost)
+			delete(entries, key)
+		}
+	}
+	return expired
+}
+
+// chargeRun charges a finished pod that was not seen in the previous cycle for the
+// part of the cycle between previous and now that it ran
+func (ca *CostAccumulator) chargeRun(run FinishedPodCost, previous, now time.Time) {
+	start, end := run.Start, run.End
+	if start.Before(previous) {
+		start = previous
+	}
+	if end.After(now) {
+		end = now
+	}
+	if !end.After(start) {
+		return
+	}
+
+	key := podAccumulatorKey(run.PodCost)
+	charge := run.HourlyCost * end.Sub(start).Hours()
+	ca.charge(ca.pods, key, podAccumulatedCost(run.PodCost), charge, now)
+	ca.charge(ca.namespaces, run.Namespace, AccumulatedCost{Namespace: run.Namespace}, charge, now)
+}
+
+// charge adds a non-negative cost to an entry, creating it if needed
+func (ca *CostAccumulator) charge(entries map[string]*accumulatedEntry, key string, cost AccumulatedCost, amount float64, now time.Time) {
+	entry, ok := entries[key]
+	if !ok {
+		entry = &accumulatedEntry{AccumulatedCost: cost}
+		entries[key] = entry
+	}
+	entry.TotalCost += amount
+	entry.LastSeen = now
+}
+
+// record sets the current hourly rate of an entry, creating it if needed
+func (ca *CostAccumulator) record(entries map[string]*accumulatedEntry, key string, cost AccumulatedCost, hourlyRate float64, now time.Time) {
+	entry, ok := entries[key]
+	if !ok {
+		entry = &accumulatedEntry{AccumulatedCost: cost}
+		entries[key] = entry
+	}
+	entry.hourlyRate += hourlyRate
+	entry.LastSeen = now
+}
+
+// podAccumulatorKey identifies a pod's accumulated cost. Pods are keyed by UID, so
+// a pod recreated with the same name on the same node is a new run; pods without a
+// UID fall back to their namespace, name and node.
+func podAccumulatorKey(pod PodCost) string {
+	if pod.UID != "" {
+		return pod.UID
+	}
+	return pod.Namespace + "/" + pod.PodName + "/" + pod.NodeName
+}
+
+// podAccumulatedCost returns an empty accumulated cost for a pod
+func podAccumulatedCost(pod PodCost) AccumulatedCost {
+	return AccumulatedCost{
+		Namespace: pod.Namespace,
+		Pod:       pod.PodName,
+		Node:      pod.NodeName,
+	}
+}
+
+// podSeriesKey identifies the exported series of a pod's accumulated cost
+func podSeriesKey(cost AccumulatedCost) string {
+	return cost.Namespace + "/" + cost.Pod + "/" + cost.Node
+}
+
+// Pods returns the accumulated cost of each pod by namespace, name and node. The
+// runs of a pod recreated with the same name on the same node are summed, so the
+// total never decreases while any run of the pod is kept.
+func (ca *CostAccumulator) Pods() []AccumulatedCost {
+	var pods []AccumulatedCost
+	index := make(map[string]int)
+	for _, cost := range accumulatedCosts(ca.pods) {
+		key := podSeriesKey(cost)
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(pods)
+			cost.TotalCost += ca.expiredRuns[key]
+			pods = append(pods, cost)
+			continue
+		}
+		pods[i].TotalCost += cost.TotalCost
+		if cost.LastSeen.After(pods[i].LastSeen) {
+			pods[i].LastSeen = cost.LastSeen
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		if pods[i].Pod != pods[j].Pod {
+			return pods[i].Pod < pods[j].Pod
+		}
+		return pods[i].Node < pods[j].Node
+	})
+	return pods
+}
+
+// Namespaces returns the accumulated cost of each namespace
+func (ca *CostAccumulator) Namespaces() []AccumulatedCost {
+	return accumulatedCosts(ca.namespaces)
+}
+
+// Nodes returns the accumulated cost of each node
+func (ca *CostAccumulator) Nodes() []AccumulatedCost {
+	return accumulatedCosts(ca.nodes)
+}
+
+// accumulatedCosts returns the costs of entries sorted by key
+func accumulatedCosts(entries map[string]*accumulatedEntry) []AccumulatedCost {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	costs := make([]AccumulatedCost, 0, len(keys))
+	for _, key := range keys {
+		costs = append(costs, entries[key].AccumulatedCost)
+	}
+	return costs
+}
@@ -0,0 +1,109 @@
+package calculator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAccumulatorChargesFinishedPodUntilItFinished(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	pod := PodCost{PodName: "job", Namespace: "batch", UID: "uid-1", NodeName: "node-1", HourlyCost: 1, StartTime: start}
+
+	tests := []struct {
+		name          string
+		namespaceRate float64
+		wantNamespace float64
+	}{
+		{name: "namespace rate includes pod", namespaceRate: 1.5, wantNamespace: 1.5 + 0.5 + 0.5},
+		{name: "namespace rate shared out", namespaceRate: 0, wantNamespace: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := NewCostAccumulator(24 * time.Hour)
+			namespaceCosts := []NamespaceCost{{Namespace: "batch", HourlyCost: tt.namespaceRate}}
+
+			var lastPod, lastNamespace float64
+			check := func(cycle string) {
+				t.Helper()
+				pods, namespaces := ca.Pods(), ca.Namespaces()
+				if len(pods) != 1 || len(namespaces) != 1 {
+					t.Fatalf("%s: got %d pods and %d namespaces, want 1 each", cycle, len(pods), len(namespaces))
+				}
+				if pods[0].TotalCost < lastPod || namespaces[0].TotalCost < lastNamespace {
+					t.Errorf("%s: totals decreased to pod %v, namespace %v from %v, %v", cycle, pods[0].TotalCost, namespaces[0].TotalCost, lastPod, lastNamespace)
+				}
+				lastPod, lastNamespace = pods[0].TotalCost, namespaces[0].TotalCost
+			}
+
+			ca.Accumulate(start, []PodCost{pod}, nil, namespaceCosts, nil)
+			check("first cycle")
+
+			ca.Accumulate(start.Add(time.Hour), []PodCost{pod}, nil, namespaceCosts, nil)
+			check("second cycle")
+
+			// The pod finished half way through the third cycle
+			finished := []FinishedPodCost{{PodCost: pod, Start: start, End: start.Add(90 * time.Minute)}}
+			ca.Accumulate(start.Add(2*time.Hour), nil, finished, nil, nil)
+			check("third cycle")
+
+			if !approxEqual(lastPod, 1.5) {
+				t.Errorf("pod total = %v, want 1.5", lastPod)
+			}
+			if !approxEqual(lastNamespace, tt.wantNamespace) {
+				t.Errorf("namespace total = %v, want %v", lastNamespace, tt.wantNamespace)
+			}
+		})
+	}
+}
+
+func TestAccumulatorChargesRunsBetweenCycles(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ca := NewCostAccumulator(24 * time.Hour)
+	ca.Accumulate(start, nil, nil, nil, nil)
+
+	run := PodCost{PodName: "job", Namespace: "batch", UID: "uid-1", NodeName: "node-1", HourlyCost: 2}
+	finished := []FinishedPodCost{{PodCost: run, Start: start.Add(15 * time.Minute), End: start.Add(45 * time.Minute)}}
+	ca.Accumulate(start.Add(time.Hour), nil, finished, nil, nil)
+
+	pods := ca.Pods()
+	if len(pods) != 1 || !approxEqual(pods[0].TotalCost, 1) {
+		t.Fatalf("pods = %+v, want one pod charged 1", pods)
+	}
+	namespaces := ca.Namespaces()
+	if len(namespaces) != 1 || !approxEqual(namespaces[0].TotalCost, 1) {
+		t.Errorf("namespaces = %+v, want one namespace charged 1", namespaces)
+	}
+}
+
+func TestAccumulatorKeepsExpiredRunsOfRecreatedPods(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := PodCost{PodName: "web-0", Namespace: "shop", UID: "uid-1", NodeName: "node-1", HourlyCost: 1, StartTime: start}
+	second := first
+	second.UID = "uid-2"
+	second.StartTime = start.Add(2 * time.Hour)
+
+	ca := NewCostAccumulator(3 * time.Hour)
+	ca.Accumulate(start, []PodCost{first}, nil, nil, nil)
+	ca.Accumulate(start.Add(time.Hour), []PodCost{first}, nil, nil, nil)
+
+	// The pod is recreated with the same name and the first run expires while the
+	// second is running
+	var last float64
+	for hour := 2; hour <= 6; hour++ {
+		ca.Accumulate(start.Add(time.Duration(hour)*time.Hour), []PodCost{second}, nil, nil, nil)
+		pods := ca.Pods()
+		if len(pods) != 1 {
+			t.Fatalf("hour %d: got %d pods, want 1", hour, len(pods))
+		}
+		if pods[0].TotalCost < last {
+			t.Errorf("hour %d: total decreased to %v from %v", hour, pods[0].TotalCost, last)
+		}
+		last = pods[0].TotalCost
+	}
+	if len(ca.pods) != 1 {
+		t.Errorf("got %d runs, want the expired run removed", len(ca.pods))
+	}
+	if !approxEqual(last, 6) {
+		t.Errorf("total = %v, want 6", last)
+	}
+}
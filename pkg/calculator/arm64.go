@@ -0,0 +1,68 @@
+package calculator
+
+import (
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+)
+
+// WorkloadArm64Equivalent estimates the cost of running a workload's amd64 pods on
+// the equivalent arm64 instance types
+type WorkloadArm64Equivalent struct {
+	Namespace      string
+	OwnerKind      string
+	OwnerName      string
+	PodCount       int
+	HourlyCost     float64 // current cost of the pods on amd64 nodes
+	Arm64Cost      float64 // estimated cost on arm64 nodes
+	SavingsHourly  float64
+	SavingsPercent float64
+}
+
+// CalculateArm64Equivalents estimates, for each workload with pods on amd64 nodes,
+// the cost on the arm64 equivalent of each node. The arm64 equivalent is priced at
+// list price, so each pod's compute cost is converted to its share of the node's
+// list price and scaled by the ratio of the arm64 equivalent's price to it. Root
+// volume costs do not depend on the architecture and are kept.
+func (cc *CostCalculator) CalculateArm64Equivalents(podCosts []PodCost, nodes []collector.NodeInfo) []WorkloadArm64Equivalent {
+	nodeMap := make(map[string]collector.NodeInfo)
+	for _, node := range nodes {
+		nodeMap[node.Name] = node
+	}
+
+	type workloadKey struct{ namespace, kind, name string }
+	workloads := make(map[workloadKey]*WorkloadArm64Equivalent)
+
+	for _, pod := range podCosts {
+		node, ok := nodeMap[pod.NodeName]
+		if !ok || node.Arm64EquivalentType == "" || node.ListPrice <= 0 || node.HourlyPrice <= 0 {
+			continue
+		}
+
+		key := workloadKey{pod.Namespace, pod.OwnerKind, pod.OwnerName}
+		workload, exists := workloads[key]
+		if !exists {
+			workload = &WorkloadArm64Equivalent{
+				Namespace: pod.Namespace,
+				OwnerKind: pod.OwnerKind,
+				OwnerName: pod.OwnerName,
+			}
+			workloads[key] = workload
+		}
+
+		workload.PodCount++
+		workload.HourlyCost += pod.HourlyCost
+		computeCost := pod.CPUCost + pod.MemoryCost + pod.GPUCost
+		listCost := computeCost * node.ListPrice / node.HourlyPrice
+		workload.Arm64Cost += pod.HourlyCost - computeCost + listCost*node.Arm64EquivalentPrice/node.ListPrice
+	}
+
+	var result []WorkloadArm64Equivalent
+	for _, workload := range workloads {
+		workload.SavingsHourly = workload.HourlyCost - workload.Arm64Cost
+		if workload.HourlyCost > 0 {
+			workload.SavingsPercent = (workload.SavingsHourly / workload.HourlyCost) * 100
+		}
+		result = append(result, *workload)
+	}
+
+	return result
+}
@@ -2,23 +2,112 @@ package calculator
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/deepcost/kube-cost-exporter/pkg/collector"
 	"github.com/sirupsen/logrus"
 )
 
+// Allocation modes select which pod resources node costs are allocated by
+const (
+	AllocationRequests = "requests" // resource requests
+	AllocationUsage    = "usage"    // average usage over the usage window
+	AllocationMax      = "max"      // the greater of requests and usage
+)
+
+// Overhead policies select who pays for node capacity reserved for the system
+// (kube-reserved, system-reserved and eviction thresholds)
+const (
+	OverheadIgnore       = "ignore"       // pods are charged by their share of capacity; overhead is unallocated
+	OverheadProportional = "proportional" // pods are charged by their share of allocatable
+	OverheadSystem       = "system"       // overhead is charged to the system namespace
+)
+
+// SystemNamespace is the synthetic namespace node overhead is charged to
+const SystemNamespace = "__system__"
+
+// Options configures a CostCalculator
+type Options struct {
+	AllocationMode      string
+	OverheadPolicy      string
+	IdleDistribution    string
+	DaemonSetAllocation string
+	CPURAMCostRatio     float64 // hourly cost of a CPU core relative to a GiB of memory
+
+	// Allocation strategy, by default and per namespace, and the weights of the
+	// weighted strategy
+	Strategy            string
+	NamespaceStrategies map[string]string
+	CPUWeight           float64
+	MemoryWeight        float64
+}
+
+// ValidAllocationMode reports whether mode is a supported allocation mode
+func ValidAllocationMode(mode string) bool {
+	switch mode {
+	case AllocationRequests, AllocationUsage, AllocationMax:
+		return true
+	}
+	return false
+}
+
+// ValidOverheadPolicy reports whether policy is a supported overhead policy
+func ValidOverheadPolicy(policy string) bool {
+	switch policy {
+	case OverheadIgnore, OverheadProportional, OverheadSystem:
+		return true
+	}
+	return false
+}
+
 // CostCalculator calculates pod costs based on resource allocation
 type CostCalculator struct {
-	logger *logrus.Logger
+	opts       Options
+	strategies map[string]AllocationStrategy // by strategy name
+	logger     *logrus.Logger
 }
 
-// NewCostCalculator creates a new cost calculator
-func NewCostCalculator() *CostCalculator {
+// NewCostCalculator creates a new cost calculator. An empty allocation mode
+// allocates by requests, an empty overhead policy ignores node overhead, DaemonSet
+// pods are charged as tenants, and idle cost is reported separately by default.
+// Pods are allocated by the unit-rate strategy unless another is configured.
+func NewCostCalculator(opts Options) *CostCalculator {
 	logger := logrus.New()
 	logger.SetLevel(logrus.InfoLevel)
 
+	if opts.AllocationMode == "" {
+		opts.AllocationMode = AllocationRequests
+	}
+	if opts.OverheadPolicy == "" {
+		opts.OverheadPolicy = OverheadIgnore
+	}
+	if opts.IdleDistribution == "" {
+		opts.IdleDistribution = IdleSeparate
+	}
+	if opts.DaemonSetAllocation == "" {
+		opts.DaemonSetAllocation = DaemonSetTenant
+	}
+	if opts.CPURAMCostRatio <= 0 {
+		opts.CPURAMCostRatio = DefaultCPURAMCostRatio
+	}
+	if opts.Strategy == "" {
+		opts.Strategy = StrategyUnitRate
+	}
+
+	strategies := make(map[string]AllocationStrategy)
+	for _, name := range append([]string{StrategyUnitRate, opts.Strategy}, namespaceStrategyNames(opts)...) {
+		strategy, ok := NewAllocationStrategy(name, opts.CPUWeight, opts.MemoryWeight)
+		if !ok {
+			logger.Warnf("Unknown allocation strategy %q, using %s", name, StrategyUnitRate)
+			continue
+		}
+		strategies[name] = strategy
+	}
+
 	return &CostCalculator{
-		logger: logger,
+		opts:       opts,
+		strategies: strategies,
+		logger:     logger,
 	}
 }
 
@@ -26,12 +115,57 @@ func NewCostCalculator() *CostCalculator {
 type PodCost struct {
 	PodName      string
 	Namespace    string
+	UID          string
 	NodeName     string
+	OwnerKind    string
+	OwnerName    string
+	Labels       map[string]string
 	HourlyCost   float64
 	DailyCost    float64
 	MonthlyCost  float64
 	CPUCost      float64
 	MemoryCost   float64
+	GPUCost      float64
+
+	// Resources the cost was allocated by, depending on the allocation mode
+	CPUAllocated              int64 // millicores
+	MemoryAllocated           int64 // bytes
+	GPUAllocated              int64
+	EphemeralStorageAllocated int64 // bytes
+	CPUUsage                  int64 // millicores
+	MemoryUsage               int64 // bytes
+
+	// Root volume cost, included in HourlyCost, and network cost from kubelet
+	// statistics. Network cost prices all egress as internet egress, so it is an
+	// upper bound and is not included in HourlyCost.
+	EphemeralStorageCost float64
+	NetworkCost          float64
+
+	// Container shares of the compute cost
+	Containers []ContainerCost
+
+	// Allocation strategy the cost was calculated with
+	Strategy string
+
+	// When the pod's first container started
+	StartTime time.Time
+
+	// Persistent volume claims the pod mounts, and its share of their hourly cost.
+	// Storage cost is not included in HourlyCost.
+	PVCs        []string
+	StorageCost float64
+}
+
+// ContainerCost represents a container's share of its pod's compute cost
+type ContainerCost struct {
+	Name          string
+	Sidecar       bool // native sidecar (restartable init container)
+	HourlyCost    float64
+	CPURequest    int64 // millicores
+	MemoryRequest int64 // bytes
+	CPUUsage      int64 // millicores
+	MemoryUsage   int64 // bytes
+	HasUsage      bool  // usage is from kubelet statistics
 }
 
 // NamespaceCost represents aggregated cost for a namespace
@@ -43,47 +177,370 @@ type NamespaceCost struct {
 	PodCount    int
 }
 
-// CalculatePodCost calculates the cost of a pod based on its resource allocation
-func (cc *CostCalculator) CalculatePodCost(pod collector.PodInfo, node collector.NodeInfo) (PodCost, error) {
-	if node.CPUCapacity == 0 || node.MemoryCapacity == 0 {
-		return PodCost{}, fmt.Errorf("node has zero capacity")
+// CalculatePodCosts calculates the cost of each pod on a known node. Pods that are
+// not scheduled yet are priced by CalculatePendingPodCosts instead.
+func (cc *CostCalculator) CalculatePodCosts(pods []collector.PodInfo, nodes []collector.NodeInfo) []PodCost {
+	nodeMap := make(map[string]collector.NodeInfo)
+	for _, node := range nodes {
+		nodeMap[node.Name] = node
+	}
+
+	podsOnNode := make(map[string]int)
+	for _, pod := range pods {
+		podsOnNode[pod.NodeName]++
+	}
+
+	var podCosts []PodCost
+	for _, pod := range pods {
+		if pod.NodeName == "" {
+			continue
+		}
+
+		node, exists := nodeMap[pod.NodeName]
+		if !exists {
+			cc.logger.Warnf("Node %s not found for pod %s/%s", pod.NodeName, pod.Namespace, pod.Name)
+			continue
+		}
+
+		podCost, err := cc.CalculatePodCost(pod, node, podsOnNode[pod.NodeName])
+		if err != nil {
+			cc.logger.Warnf("Failed to calculate cost for pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			continue
+		}
+
+		podCosts = append(podCosts, podCost)
+	}
+
+	return podCosts
+}
+
+// CalculateFinishedPodCosts calculates the hourly cost of finished pod runs on the
+// nodes they ran on. Runs on nodes that are gone are skipped.
+func (cc *CostCalculator) CalculateFinishedPodCosts(runs []collector.PodRun, nodes []collector.NodeInfo, podCosts []PodCost) []FinishedPodCost {
+	nodeMap := make(map[string]collector.NodeInfo)
+	for _, node := range nodes {
+		nodeMap[node.Name] = node
+	}
+
+	podsOnNode := make(map[string]int)
+	for _, pod := range podCosts {
+		podsOnNode[pod.NodeName]++
 	}
 
-	// Calculate CPU cost allocation
-	// Pod Cost = (Pod Resource Request / Node Total Capacity) × Node Hourly Cost
-	cpuFraction := float64(pod.CPURequest) / float64(node.CPUCapacity)
-	memoryFraction := float64(pod.MemoryRequest) / float64(node.MemoryCapacity)
+	var finished []FinishedPodCost
+	for _, run := range runs {
+		node, exists := nodeMap[run.Pod.NodeName]
+		if !exists {
+			cc.logger.Debugf("Node %s not found for finished pod %s/%s", run.Pod.NodeName, run.Pod.Namespace, run.Pod.Name)
+			continue
+		}
+
+		podCost, err := cc.CalculatePodCost(run.Pod, node, podsOnNode[run.Pod.NodeName]+1)
+		if err != nil {
+			cc.logger.Warnf("Failed to calculate cost for finished pod %s/%s: %v", run.Pod.Namespace, run.Pod.Name, err)
+			continue
+		}
 
-	// Use the maximum of CPU and memory fraction for more accurate cost allocation
-	// This accounts for pods that are either CPU or memory bound
-	resourceFraction := cpuFraction
-	if memoryFraction > cpuFraction {
-		resourceFraction = memoryFraction
+		finished = append(finished, FinishedPodCost{PodCost: podCost, Start: run.Start, End: run.End})
 	}
 
-	// If no requests are set, use a minimal fraction
-	if pod.CPURequest == 0 && pod.MemoryRequest == 0 {
-		resourceFraction = 0.01 // Assign 1% of node cost
+	return finished
+}
+
+// CalculatePodCost calculates the cost of a pod with the allocation strategy of its
+// namespace. podsOnNode is the number of pods on the node, including this one.
+func (cc *CostCalculator) CalculatePodCost(pod collector.PodInfo, node collector.NodeInfo, podsOnNode int) (PodCost, error) {
+	if node.CPUCapacity == 0 || node.MemoryCapacity == 0 {
+		return PodCost{}, fmt.Errorf("node has zero capacity")
 	}
 
-	hourlyCost := node.HourlyPrice * resourceFraction
+	cpuAllocated, memoryAllocated := cc.allocatedResources(pod)
+	cpuCapacity, memoryCapacity := cc.allocationCapacity(node)
+	cpuReserved, memoryReserved := cc.reservedResources(node)
 
-	// Calculate individual component costs for visibility
-	cpuCost := node.HourlyPrice * cpuFraction
-	memoryCost := node.HourlyPrice * memoryFraction
+	// If no requests (or usage) are set, allocate 1% of the node's capacity
+	if cpuAllocated == 0 && memoryAllocated == 0 {
+		cpuAllocated, memoryAllocated = cpuCapacity/100, memoryCapacity/100
+	}
+
+	rates := cc.CalculateUnitRates(node)
+	strategyName, strategy := cc.strategyFor(pod.Namespace)
+	costs := strategy.Allocate(AllocationInput{
+		Pod:            pod,
+		Node:           node,
+		Rates:          rates,
+		PodsOnNode:     podsOnNode,
+		CPU:            cpuAllocated,
+		Memory:         memoryAllocated,
+		CPUCapacity:    cpuCapacity,
+		MemoryCapacity: memoryCapacity,
+		CPUReserved:    cpuReserved,
+		MemoryReserved: memoryReserved,
+	})
+	cpuCost, memoryCost, gpuCost := costs.CPU, costs.Memory, costs.GPU
+
+	computeCost := cpuCost + memoryCost + gpuCost
+	ephemeralStorageAllocated := cc.allocatedEphemeralStorage(pod)
+	ephemeralStorageCost := float64(ephemeralStorageAllocated) / (1024 * 1024 * 1024) * rates.EphemeralStorageGiBHourly
+	// All egress is priced as internet egress, so this is an upper bound
+	networkCost := pod.NetworkTxBytesPerHour / (1024 * 1024 * 1024) * node.NetworkPricePerGB
+	hourlyCost := computeCost + ephemeralStorageCost
 
 	return PodCost{
 		PodName:     pod.Name,
 		Namespace:   pod.Namespace,
+		UID:         pod.UID,
 		NodeName:    pod.NodeName,
+		OwnerKind:   pod.OwnerKind,
+		OwnerName:   pod.OwnerName,
+		Labels:      pod.Labels,
 		HourlyCost:  hourlyCost,
 		DailyCost:   hourlyCost * 24,
 		MonthlyCost: hourlyCost * 730, // Average hours per month
 		CPUCost:     cpuCost,
 		MemoryCost:  memoryCost,
+		GPUCost:     gpuCost,
+
+		CPUAllocated:              cpuAllocated,
+		MemoryAllocated:           memoryAllocated,
+		GPUAllocated:              pod.GPURequest,
+		EphemeralStorageAllocated: ephemeralStorageAllocated,
+		CPUUsage:                  pod.CPUUsage,
+		MemoryUsage:               pod.MemoryUsage,
+
+		EphemeralStorageCost: ephemeralStorageCost,
+		NetworkCost:          networkCost,
+		Containers:           containerCosts(pod, computeCost),
+
+		Strategy:  strategyName,
+		StartTime: pod.StartTime,
+
+		PVCs: pod.PVCs,
 	}, nil
 }
 
+// strategyFor returns the allocation strategy of a namespace and its name
+func (cc *CostCalculator) strategyFor(namespace string) (string, AllocationStrategy) {
+	name, ok := cc.opts.NamespaceStrategies[namespace]
+	if !ok {
+		name = cc.opts.Strategy
+	}
+	if strategy, ok := cc.strategies[name]; ok {
+		return name, strategy
+	}
+	return StrategyUnitRate, cc.strategies[StrategyUnitRate]
+}
+
+// namespaceStrategyNames returns the strategies configured for namespaces
+func namespaceStrategyNames(opts Options) []string {
+	var names []string
+	for _, name := range opts.NamespaceStrategies {
+		names = append(names, name)
+	}
+	return names
+}
+
+// containerCosts splits a pod's compute cost between its app containers and native
+// sidecars. With kubelet statistics, the whole cost is split by their average share
+// of the pod's CPU and memory usage. Otherwise each container is charged its
+// average share of the pod's CPU and memory requests, and the rest of the cost is
+// the pod's overhead and init containers.
+func containerCosts(pod collector.PodInfo, computeCost float64) []ContainerCost {
+	resources := make(map[string]collector.ContainerResources, len(pod.ContainerResources))
+	for _, container := range pod.ContainerResources {
+		resources[container.Name] = container
+	}
+
+	if len(pod.Containers) > 0 {
+		var cpuTotal, memoryTotal int64
+		for _, container := range pod.Containers {
+			cpuTotal += container.CPUUsage
+			memoryTotal += container.MemoryUsage
+		}
+
+		costs := make([]ContainerCost, 0, len(pod.Containers))
+		for _, container := range pod.Containers {
+			share := containerShare(container.CPUUsage, cpuTotal, container.MemoryUsage, memoryTotal, len(pod.Containers))
+			costs = append(costs, ContainerCost{
+				Name:          container.Name,
+				Sidecar:       resources[container.Name].Sidecar,
+				HourlyCost:    computeCost * share,
+				CPURequest:    resources[container.Name].CPURequest,
+				MemoryRequest: resources[container.Name].MemoryRequest,
+				CPUUsage:      container.CPUUsage,
+				MemoryUsage:   container.MemoryUsage,
+				HasUsage:      true,
+			})
+		}
+		return costs
+	}
+
+	if len(pod.ContainerResources) == 0 {
+		return nil
+	}
+
+	costs := make([]ContainerCost, 0, len(pod.ContainerResources))
+	for _, container := range pod.ContainerResources {
+		share := containerShare(container.CPURequest, pod.CPURequest, container.MemoryRequest, pod.MemoryRequest, len(pod.ContainerResources))
+		costs = append(costs, ContainerCost{
+			Name:          container.Name,
+			Sidecar:       container.Sidecar,
+			HourlyCost:    computeCost * share,
+			CPURequest:    container.CPURequest,
+			MemoryRequest: container.MemoryRequest,
+		})
+	}
+	return costs
+}
+
+// containerShare returns a container's average share of its pod's CPU and memory,
+// or an equal share of the pod's containers if the pod has neither
+func containerShare(cpu, cpuTotal, memory, memoryTotal int64, containers int) float64 {
+	switch {
+	case cpuTotal > 0 && memoryTotal > 0:
+		return (float64(cpu)/float64(cpuTotal) + float64(memory)/float64(memoryTotal)) / 2
+	case cpuTotal > 0:
+		return float64(cpu) / float64(cpuTotal)
+	case memoryTotal > 0:
+		return float64(memory) / float64(memoryTotal)
+	}
+	return 1 / float64(containers)
+}
+
+// allocatedResources returns the CPU (millicores) and memory (bytes) a pod's cost is
+// allocated by. Pods without usage samples are allocated by requests.
+func (cc *CostCalculator) allocatedResources(pod collector.PodInfo) (int64, int64) {
+	if !pod.HasUsage {
+		return pod.CPURequest, pod.MemoryRequest
+	}
+
+	switch cc.opts.AllocationMode {
+	case AllocationUsage:
+		return pod.CPUUsage, pod.MemoryUsage
+	case AllocationMax:
+		return max(pod.CPURequest, pod.CPUUsage), max(pod.MemoryRequest, pod.MemoryUsage)
+	}
+	return pod.CPURequest, pod.MemoryRequest
+}
+
+// allocatedEphemeralStorage returns the ephemeral storage (bytes) a pod's share of
+// its node's root volume cost is allocated by. Pods without kubelet statistics are
+// allocated by requests.
+func (cc *CostCalculator) allocatedEphemeralStorage(pod collector.PodInfo) int64 {
+	if !pod.HasEphemeralStorageUsage {
+		return pod.EphemeralStorageRequest
+	}
+
+	switch cc.opts.AllocationMode {
+	case AllocationUsage:
+		return pod.EphemeralStorageUsage
+	case AllocationMax:
+		return max(pod.EphemeralStorageRequest, pod.EphemeralStorageUsage)
+	}
+	return pod.EphemeralStorageRequest
+}
+
+// allocationCapacity returns the node CPU (millicores) and memory (bytes) pod
+// allocations are divided by. The proportional overhead policy divides by
+// allocatable, so the overhead is spread across pods by their allocation.
+func (cc *CostCalculator) allocationCapacity(node collector.NodeInfo) (int64, int64) {
+	if cc.opts.OverheadPolicy != OverheadProportional || node.CPUAllocatable == 0 || node.MemoryAllocatable == 0 {
+		return node.CPUCapacity, node.MemoryCapacity
+	}
+	return node.CPUAllocatable, node.MemoryAllocatable
+}
+
+// reservedResources returns the node CPU (millicores) and memory (bytes) reserved
+// for the system that is charged to the system namespace rather than to pods,
+// which is only the case under the system overhead policy
+func (cc *CostCalculator) reservedResources(node collector.NodeInfo) (int64, int64) {
+	if cc.opts.OverheadPolicy != OverheadSystem {
+		return 0, 0
+	}
+	return max(node.CPUCapacity-node.CPUAllocatable, 0), max(node.MemoryCapacity-node.MemoryAllocatable, 0)
+}
+
+// ephemeralStorageCapacity returns the node ephemeral storage (bytes) pod
+// allocations are divided by, which is allocatable under the proportional
+// overhead policy like allocationCapacity
+func (cc *CostCalculator) ephemeralStorageCapacity(node collector.NodeInfo) int64 {
+	if cc.opts.OverheadPolicy != OverheadProportional || node.EphemeralStorageAllocatable == 0 {
+		return node.EphemeralStorageCapacity
+	}
+	return node.EphemeralStorageAllocatable
+}
+
+// OverheadCost is the cost of a node's capacity reserved for the system
+type OverheadCost struct {
+	Node                 string
+	HourlyCost           float64
+	CPUCost              float64
+	MemoryCost           float64
+	EphemeralStorageCost float64
+
+	// Reserved resources, which are allocated to the system rather than idle
+	CPUAllocated              int64 // millicores
+	MemoryAllocated           int64 // bytes
+	EphemeralStorageAllocated int64 // bytes
+}
+
+// CalculateOverheadCosts returns the cost of each node's capacity reserved for the
+// system, which AddOverheadNamespaceCosts charges to the system namespace. It
+// returns nothing unless the overhead policy is system.
+func (cc *CostCalculator) CalculateOverheadCosts(nodes []collector.NodeInfo) []OverheadCost {
+	if cc.opts.OverheadPolicy != OverheadSystem {
+		return nil
+	}
+
+	var overheadCosts []OverheadCost
+	for _, node := range nodes {
+		if node.CPUCapacity == 0 || node.MemoryCapacity == 0 {
+			continue
+		}
+
+		cpuOverhead, memoryOverhead := cc.reservedResources(node)
+		ephemeralStorageOverhead := max(node.EphemeralStorageCapacity-node.EphemeralStorageAllocatable, 0)
+		if cpuOverhead == 0 && memoryOverhead == 0 && ephemeralStorageOverhead == 0 {
+			continue
+		}
+
+		rates := cc.CalculateUnitRates(node)
+		cpuCost := float64(cpuOverhead) / 1000 * rates.CPUCoreHourly
+		memoryCost := float64(memoryOverhead) / (1024 * 1024 * 1024) * rates.MemoryGiBHourly
+		ephemeralStorageCost := float64(ephemeralStorageOverhead) / (1024 * 1024 * 1024) * rates.EphemeralStorageGiBHourly
+		hourlyCost := cpuCost + memoryCost + ephemeralStorageCost
+
+		overheadCosts = append(overheadCosts, OverheadCost{
+			Node:                 node.Name,
+			HourlyCost:           hourlyCost,
+			CPUCost:              cpuCost,
+			MemoryCost:           memoryCost,
+			EphemeralStorageCost: ephemeralStorageCost,
+
+			CPUAllocated:              cpuOverhead,
+			MemoryAllocated:           memoryOverhead,
+			EphemeralStorageAllocated: ephemeralStorageOverhead,
+		})
+	}
+
+	return overheadCosts
+}
+
+// AddOverheadNamespaceCosts charges node overhead to the system namespace. Overhead
+// is charged at the namespace level only, so it is not part of pod, workload or
+// dimension costs.
+func (cc *CostCalculator) AddOverheadNamespaceCosts(namespaceCosts []NamespaceCost, overheadCosts []OverheadCost) []NamespaceCost {
+	if len(overheadCosts) == 0 {
+		return namespaceCosts
+	}
+
+	var hourlyCost float64
+	for _, overhead := range overheadCosts {
+		hourlyCost += overhead.HourlyCost
+	}
+	return chargeNamespace(namespaceCosts, SystemNamespace, hourlyCost)
+}
+
 // CalculateNamespaceCosts aggregates pod costs by namespace
 func (cc *CostCalculator) CalculateNamespaceCosts(podCosts []PodCost) []NamespaceCost {
 	namespaceMap := make(map[string]*NamespaceCost)
@@ -112,6 +569,63 @@ func (cc *CostCalculator) CalculateNamespaceCosts(podCosts []PodCost) []Namespac
 	return namespaceCosts
 }
 
+// chargeNamespace adds an hourly cost that is not part of any pod to a namespace,
+// adding the namespace if it has no pods
+func chargeNamespace(namespaceCosts []NamespaceCost, namespace string, hourlyCost float64) []NamespaceCost {
+	for i := range namespaceCosts {
+		if namespaceCosts[i].Namespace == namespace {
+			addNamespaceCost(&namespaceCosts[i], hourlyCost)
+			return namespaceCosts
+		}
+	}
+	ns := NamespaceCost{Namespace: namespace}
+	addNamespaceCost(&ns, hourlyCost)
+	return append(namespaceCosts, ns)
+}
+
+// WorkloadCost represents aggregated cost for a workload (Deployment, StatefulSet, CronJob, ...)
+type WorkloadCost struct {
+	Namespace   string
+	Kind        string
+	Name        string
+	HourlyCost  float64
+	DailyCost   float64
+	MonthlyCost float64
+	PodCount    int
+	StorageCost float64 // hourly, not included in HourlyCost
+}
+
+// CalculateWorkloadCosts aggregates pod costs by their top-level owner
+func (cc *CostCalculator) CalculateWorkloadCosts(podCosts []PodCost) []WorkloadCost {
+	type workloadKey struct{ namespace, kind, name string }
+	workloadMap := make(map[workloadKey]*WorkloadCost)
+
+	for _, podCost := range podCosts {
+		key := workloadKey{podCost.Namespace, podCost.OwnerKind, podCost.OwnerName}
+		workload, exists := workloadMap[key]
+		if !exists {
+			workload = &WorkloadCost{
+				Namespace: podCost.Namespace,
+				Kind:      podCost.OwnerKind,
+				Name:      podCost.OwnerName,
+			}
+			workloadMap[key] = workload
+		}
+
+		workload.HourlyCost += podCost.HourlyCost
+		workload.DailyCost += podCost.DailyCost
+		workload.MonthlyCost += podCost.MonthlyCost
+		workload.PodCount++
+	}
+
+	var workloadCosts []WorkloadCost
+	for _, workload := range workloadMap {
+		workloadCosts = append(workloadCosts, *workload)
+	}
+
+	return workloadCosts
+}
+
 // SpotSavings contains detailed spot instance savings information
 type SpotSavings struct {
 	TotalSavingsHourly   float64
@@ -237,12 +751,13 @@ func (cc *CostCalculator) CalculateNamespaceSpotUsage(podCosts []PodCost, nodes
 	return result
 }
 
-// CalculateTotalClusterCost calculates the total cluster cost
+// CalculateTotalClusterCost calculates the total cluster cost: node prices and
+// their root volumes, so that it adds up to the namespace and idle costs
 func (cc *CostCalculator) CalculateTotalClusterCost(nodes []collector.NodeInfo) float64 {
 	var totalCost float64
 
 	for _, node := range nodes {
-		totalCost += node.HourlyPrice
+		totalCost += node.TotalHourlyCost()
 	}
 
 	return totalCost
@@ -0,0 +1,58 @@
+package calculator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+)
+
+const gib = 1024 * 1024 * 1024
+
+// approxEqual reports whether two costs are equal to within rounding
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// testNode returns a 4 core, 16 GiB node at $0.20 an hour
+func testNode(name string) collector.NodeInfo {
+	return collector.NodeInfo{
+		Name:              name,
+		NodePool:          "default",
+		InstanceType:      "m5.xlarge",
+		HourlyPrice:       0.2,
+		ListPrice:         0.2,
+		CPUCapacity:       4000,
+		MemoryCapacity:    16 * gib,
+		CPUAllocatable:    4000,
+		MemoryAllocatable: 16 * gib,
+	}
+}
+
+func TestCalculatePodCostExcludesNetworkCost(t *testing.T) {
+	cc := NewCostCalculator(Options{})
+	node := testNode("node-1")
+	node.NetworkPricePerGB = 0.09
+	pod := collector.PodInfo{
+		Name:                  "web",
+		Namespace:             "shop",
+		NodeName:              node.Name,
+		CPURequest:            1000,
+		MemoryRequest:         4 * gib,
+		NetworkTxBytesPerHour: 10 * gib,
+	}
+
+	cost, err := cc.CalculatePodCost(pod, node, 1)
+	if err != nil {
+		t.Fatalf("CalculatePodCost: %v", err)
+	}
+	if want := 10 * 0.09; !approxEqual(cost.NetworkCost, want) {
+		t.Errorf("NetworkCost = %v, want %v", cost.NetworkCost, want)
+	}
+	if want := cost.CPUCost + cost.MemoryCost + cost.GPUCost + cost.EphemeralStorageCost; !approxEqual(cost.HourlyCost, want) {
+		t.Errorf("HourlyCost = %v, want compute and ephemeral storage cost %v", cost.HourlyCost, want)
+	}
+	if total := cc.CalculateTotalClusterCost([]collector.NodeInfo{node}); !approxEqual(total, node.HourlyPrice) {
+		t.Errorf("CalculateTotalClusterCost = %v, want node price %v", total, node.HourlyPrice)
+	}
+}
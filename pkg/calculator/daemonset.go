@@ -0,0 +1,148 @@
+package calculator
+
+import (
+	"sort"
+)
+
+// DaemonSet allocations select who pays for DaemonSet pods
+const (
+	DaemonSetTenant   = "tenant"   // DaemonSet pods are charged like any other pod
+	DaemonSetOverhead = "overhead" // DaemonSet pod cost is node overhead, charged to the other pods on the node
+)
+
+// ValidDaemonSetAllocation reports whether allocation is a supported DaemonSet allocation
+func ValidDaemonSetAllocation(allocation string) bool {
+	switch allocation {
+	case DaemonSetTenant, DaemonSetOverhead:
+		return true
+	}
+	return false
+}
+
+// DaemonSetCost is the cost of a DaemonSet's pods across all nodes
+type DaemonSetCost struct {
+	Namespace  string
+	Name       string
+	HourlyCost float64
+	NodeCount  int
+}
+
+// CalculateDaemonSetCosts sums the cost of each DaemonSet's pods across the fleet
+func (cc *CostCalculator) CalculateDaemonSetCosts(podCosts []PodCost) []DaemonSetCost {
+	type daemonSetKey struct{ namespace, name string }
+	daemonSetMap := make(map[daemonSetKey]*DaemonSetCost)
+
+	for _, podCost := range podCosts {
+		if podCost.OwnerKind != "DaemonSet" {
+			continue
+		}
+		key := daemonSetKey{podCost.Namespace, podCost.OwnerName}
+		daemonSet, exists := daemonSetMap[key]
+		if !exists {
+			daemonSet = &DaemonSetCost{Namespace: podCost.Namespace, Name: podCost.OwnerName}
+			daemonSetMap[key] = daemonSet
+		}
+		daemonSet.HourlyCost += podCost.HourlyCost
+		daemonSet.NodeCount++
+	}
+
+	var daemonSetCosts []DaemonSetCost
+	for _, daemonSet := range daemonSetMap {
+		daemonSetCosts = append(daemonSetCosts, *daemonSet)
+	}
+	sort.Slice(daemonSetCosts, func(i, j int) bool {
+		if daemonSetCosts[i].Namespace != daemonSetCosts[j].Namespace {
+			return daemonSetCosts[i].Namespace < daemonSetCosts[j].Namespace
+		}
+		return daemonSetCosts[i].Name < daemonSetCosts[j].Name
+	})
+
+	return daemonSetCosts
+}
+
+// RedistributeDaemonSetCosts charges the cost of DaemonSet pods to the other pods
+// on the same node in proportion to their cost, when DaemonSet pods are node
+// overhead. Container costs are scaled with their pod's compute cost. DaemonSet
+// pods are kept with zero cost so their allocations still count towards node
+// usage. On nodes with no other pods, DaemonSet pods keep their cost.
+func (cc *CostCalculator) RedistributeDaemonSetCosts(podCosts []PodCost) []PodCost {
+	if cc.opts.DaemonSetAllocation != DaemonSetOverhead {
+		return podCosts
+	}
+
+	type nodePods struct {
+		daemonSets []int
+		tenants    []int
+		tenantCost float64
+	}
+	nodes := make(map[string]*nodePods)
+	for i, podCost := range podCosts {
+		node, ok := nodes[podCost.NodeName]
+		if !ok {
+			node = &nodePods{}
+			nodes[podCost.NodeName] = node
+		}
+		if podCost.OwnerKind == "DaemonSet" {
+			node.daemonSets = append(node.daemonSets, i)
+		} else {
+			node.tenants = append(node.tenants, i)
+			node.tenantCost += podCost.HourlyCost
+		}
+	}
+
+	for _, node := range nodes {
+		if len(node.daemonSets) == 0 || len(node.tenants) == 0 {
+			continue
+		}
+
+		var overhead PodCost
+		for _, i := range node.daemonSets {
+			overhead.HourlyCost += podCosts[i].HourlyCost
+			overhead.CPUCost += podCosts[i].CPUCost
+			overhead.MemoryCost += podCosts[i].MemoryCost
+			overhead.GPUCost += podCosts[i].GPUCost
+			overhead.EphemeralStorageCost += podCosts[i].EphemeralStorageCost
+			scalePodCost(&podCosts[i], 0)
+		}
+
+		for _, i := range node.tenants {
+			share := 1 / float64(len(node.tenants))
+			if node.tenantCost > 0 {
+				share = podCosts[i].HourlyCost / node.tenantCost
+			}
+			computeCost := podCosts[i].CPUCost + podCosts[i].MemoryCost + podCosts[i].GPUCost
+			podCosts[i].HourlyCost += overhead.HourlyCost * share
+			podCosts[i].DailyCost = podCosts[i].HourlyCost * 24
+			podCosts[i].MonthlyCost = podCosts[i].HourlyCost * 730
+			podCosts[i].CPUCost += overhead.CPUCost * share
+			podCosts[i].MemoryCost += overhead.MemoryCost * share
+			podCosts[i].GPUCost += overhead.GPUCost * share
+			podCosts[i].EphemeralStorageCost += overhead.EphemeralStorageCost * share
+
+			// Containers share the DaemonSet overhead like the rest of the pod's
+			// compute cost
+			if computeCost > 0 {
+				factor := (podCosts[i].CPUCost + podCosts[i].MemoryCost + podCosts[i].GPUCost) / computeCost
+				for j := range podCosts[i].Containers {
+					podCosts[i].Containers[j].HourlyCost *= factor
+				}
+			}
+		}
+	}
+
+	return podCosts
+}
+
+// scalePodCost scales all of a pod's costs included in its hourly cost by factor
+func scalePodCost(podCost *PodCost, factor float64) {
+	podCost.HourlyCost *= factor
+	podCost.DailyCost *= factor
+	podCost.MonthlyCost *= factor
+	podCost.CPUCost *= factor
+	podCost.MemoryCost *= factor
+	podCost.GPUCost *= factor
+	podCost.EphemeralStorageCost *= factor
+	for i := range podCost.Containers {
+		podCost.Containers[i].HourlyCost *= factor
+	}
+}
@@ -0,0 +1,83 @@
+package calculator
+
+import (
+	"sort"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+	"github.com/deepcost/kube-cost-exporter/pkg/config"
+)
+
+// UnallocatedValue is the dimension value of pods without any of its sources
+const UnallocatedValue = "__unallocated__"
+
+// DimensionCost represents aggregated pod cost for a value of a dimension
+type DimensionCost struct {
+	Dimension   string
+	Value       string
+	HourlyCost  float64
+	DailyCost   float64
+	MonthlyCost float64
+	PodCount    int
+}
+
+// CalculateDimensionCosts aggregates pod costs by each dimension's value. A pod's
+// value is taken from the first of the dimension's sources set on the pod or its
+// namespace.
+func (cc *CostCalculator) CalculateDimensionCosts(podCosts []PodCost, namespaces []collector.NamespaceInfo, dimensions []config.Dimension) []DimensionCost {
+	namespaceMap := make(map[string]collector.NamespaceInfo, len(namespaces))
+	for _, ns := range namespaces {
+		namespaceMap[ns.Name] = ns
+	}
+
+	type dimensionKey struct{ dimension, value string }
+	dimensionMap := make(map[dimensionKey]*DimensionCost)
+
+	for _, dimension := range dimensions {
+		for _, podCost := range podCosts {
+			value := dimensionValue(dimension, podCost, namespaceMap[podCost.Namespace])
+			key := dimensionKey{dimension.Name, value}
+			cost, exists := dimensionMap[key]
+			if !exists {
+				cost = &DimensionCost{Dimension: dimension.Name, Value: value}
+				dimensionMap[key] = cost
+			}
+
+			cost.HourlyCost += podCost.HourlyCost
+			cost.DailyCost += podCost.DailyCost
+			cost.MonthlyCost += podCost.MonthlyCost
+			cost.PodCount++
+		}
+	}
+
+	var dimensionCosts []DimensionCost
+	for _, cost := range dimensionMap {
+		dimensionCosts = append(dimensionCosts, *cost)
+	}
+	sort.Slice(dimensionCosts, func(i, j int) bool {
+		if dimensionCosts[i].Dimension != dimensionCosts[j].Dimension {
+			return dimensionCosts[i].Dimension < dimensionCosts[j].Dimension
+		}
+		return dimensionCosts[i].Value < dimensionCosts[j].Value
+	})
+
+	return dimensionCosts
+}
+
+// dimensionValue returns a pod's value for a dimension, or UnallocatedValue
+func dimensionValue(dimension config.Dimension, podCost PodCost, ns collector.NamespaceInfo) string {
+	for _, source := range dimension.Sources {
+		var value string
+		switch {
+		case source.PodLabel != "":
+			value = podCost.Labels[source.PodLabel]
+		case source.NamespaceLabel != "":
+			value = ns.Labels[source.NamespaceLabel]
+		case source.NamespaceAnnotation != "":
+			value = ns.Annotations[source.NamespaceAnnotation]
+		}
+		if value != "" {
+			return value
+		}
+	}
+	return UnallocatedValue
+}
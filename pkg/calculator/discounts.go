@@ -0,0 +1,266 @@
+package calculator
+
+import (
+	"time"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+	"github.com/deepcost/kube-cost-exporter/pkg/config"
+	"github.com/deepcost/kube-cost-exporter/pkg/pricing"
+)
+
+// NodeDiscount contains the discounts applied to a single node
+type NodeDiscount struct {
+	NodeName            string
+	ListPrice           float64
+	EffectivePrice      float64
+	SustainedUseSavings float64
+	CommittedUseSavings float64
+	MonthFraction       float64 // fraction of the billing month the node's region and family has run
+}
+
+// CommitmentCoverage shows how much of a region/family's usage a commitment covers
+type CommitmentCoverage struct {
+	Region            string
+	Family            string
+	CommittedVCPUs    float64
+	UsedVCPUs         float64
+	CommittedMemoryGB float64
+	UsedMemoryGB      float64
+	VCPUCoverage      float64 // 0-1
+	MemoryCoverage    float64 // 0-1
+}
+
+// DiscountSummary contains discount savings across the cluster
+type DiscountSummary struct {
+	Nodes                     []NodeDiscount
+	Coverage                  []CommitmentCoverage
+	SustainedUseSavingsHourly float64
+	CommittedUseSavingsHourly float64
+}
+
+// sustainedUseKey identifies the usage sustained-use discounts are computed over
+type sustainedUseKey struct{ region, family string }
+
+// sustainedUse is the vCPU and memory usage of a region and machine family this
+// billing month
+type sustainedUse struct {
+	vcpuHours     float64
+	memoryGBHours float64
+}
+
+// SustainedUseTracker records the vCPU and memory hours run by on-demand GCP nodes
+// per region and machine family in the current billing month. GCP computes
+// sustained-use discounts over a family's combined usage rather than per VM, so the
+// record carries over when nodes are replaced. Usage before the agent started is
+// counted from the creation time of the nodes still running.
+type SustainedUseTracker struct {
+	month      time.Time // start of the tracked billing month
+	lastUpdate time.Time
+	usage      map[sustainedUseKey]*sustainedUse
+}
+
+// NewSustainedUseTracker creates a new sustained-use tracker
+func NewSustainedUseTracker() *SustainedUseTracker {
+	return &SustainedUseTracker{usage: make(map[sustainedUseKey]*sustainedUse)}
+}
+
+// record adds the usage of nodes since the previous cycle, or since they were
+// created if that was later, starting a new record each billing month
+func (st *SustainedUseTracker) record(nodes []collector.NodeInfo, monthStart, now time.Time) {
+	if !st.month.Equal(monthStart) {
+		st.month = monthStart
+		st.lastUpdate = time.Time{}
+		st.usage = make(map[sustainedUseKey]*sustainedUse)
+	}
+
+	for _, node := range nodes {
+		start := node.CreationTime
+		if start.Before(st.lastUpdate) {
+			start = st.lastUpdate
+		}
+		if start.Before(monthStart) {
+			start = monthStart
+		}
+		hours := now.Sub(start).Hours()
+		if hours <= 0 {
+			continue
+		}
+
+		key := sustainedUseKey{node.Region, pricing.GCPMachineFamily(node.InstanceType)}
+		u, ok := st.usage[key]
+		if !ok {
+			u = &sustainedUse{}
+			st.usage[key] = u
+		}
+		u.vcpuHours += float64(node.CPUCapacity) / 1000 * hours
+		u.memoryGBHours += float64(node.MemoryCapacity) / (1024 * 1024 * 1024) * hours
+	}
+	st.lastUpdate = now
+}
+
+// ApplyGCPDiscounts models GCP committed-use and sustained-use discounts for on-demand
+// GCP nodes, updating each node's HourlyPrice to its effective rate. Commitments cover
+// vCPU and memory usage per region and machine family first; sustained-use discounts
+// apply to the remaining usage based on the vCPU and memory hours the region and
+// family has run this billing month, as recorded by the tracker, relative to its
+// current size.
+func (cc *CostCalculator) ApplyGCPDiscounts(nodes []collector.NodeInfo, gcpConfig config.GCPConfig, tracker *SustainedUseTracker, now time.Time) ([]collector.NodeInfo, DiscountSummary) {
+	var summary DiscountSummary
+
+	type familyKey struct{ region, family string }
+	type familyUsage struct {
+		vcpus, memoryGB                   float64
+		committedVCPUs, committedMemoryGB float64
+		vcpuDiscount, memoryDiscount      float64 // weighted average commitment discount rate
+	}
+	usage := make(map[familyKey]*familyUsage)
+
+	eligible := func(node collector.NodeInfo) bool {
+		return node.CloudProvider == "gcp" && !node.IsSpot && node.ListPrice > 0
+	}
+
+	// Sum on-demand usage per region and family
+	for _, node := range nodes {
+		if !eligible(node) {
+			continue
+		}
+		key := familyKey{node.Region, pricing.GCPMachineFamily(node.InstanceType)}
+		u, ok := usage[key]
+		if !ok {
+			u = &familyUsage{}
+			usage[key] = u
+		}
+		u.vcpus += float64(node.CPUCapacity) / 1000
+		u.memoryGB += float64(node.MemoryCapacity) / (1024 * 1024 * 1024)
+	}
+
+	// Add commitments, tracking the blended discount rate across terms
+	for _, commitment := range gcpConfig.Commitments {
+		u, ok := usage[familyKey{commitment.Region, commitment.Family}]
+		if !ok {
+			continue
+		}
+		rate := pricing.GCPCommittedUseDiscount(commitment.Family, commitment.Term)
+		if total := u.committedVCPUs + commitment.VCPUs; total > 0 {
+			u.vcpuDiscount = (u.vcpuDiscount*u.committedVCPUs + rate*commitment.VCPUs) / total
+		}
+		if total := u.committedMemoryGB + commitment.MemoryGB; total > 0 {
+			u.memoryDiscount = (u.memoryDiscount*u.committedMemoryGB + rate*commitment.MemoryGB) / total
+		}
+		u.committedVCPUs += commitment.VCPUs
+		u.committedMemoryGB += commitment.MemoryGB
+	}
+
+	for key, u := range usage {
+		if u.committedVCPUs == 0 && u.committedMemoryGB == 0 {
+			continue
+		}
+		summary.Coverage = append(summary.Coverage, CommitmentCoverage{
+			Region:            key.region,
+			Family:            key.family,
+			CommittedVCPUs:    u.committedVCPUs,
+			UsedVCPUs:         u.vcpus,
+			CommittedMemoryGB: u.committedMemoryGB,
+			UsedMemoryGB:      u.memoryGB,
+			VCPUCoverage:      coverageRatio(u.committedVCPUs, u.vcpus),
+			MemoryCoverage:    coverageRatio(u.committedMemoryGB, u.memoryGB),
+		})
+	}
+
+	monthStart := gcpBillingMonthStart(now)
+	monthHours := monthStart.AddDate(0, 1, 0).Sub(monthStart).Hours()
+
+	var onDemand []collector.NodeInfo
+	for _, node := range nodes {
+		if eligible(node) {
+			onDemand = append(onDemand, node)
+		}
+	}
+	tracker.record(onDemand, monthStart, now)
+
+	result := make([]collector.NodeInfo, len(nodes))
+	for i, node := range nodes {
+		result[i] = node
+		if !eligible(node) {
+			continue
+		}
+
+		family := pricing.GCPMachineFamily(node.InstanceType)
+		u := usage[familyKey{node.Region, family}]
+
+		// Split the node price between vCPU and memory so each can be covered separately
+		cpuShare := 0.5
+		vcpus := float64(node.CPUCapacity) / 1000
+		memoryGB := float64(node.MemoryCapacity) / (1024 * 1024 * 1024)
+		if rates, ok := pricing.GCPComponentRates(family); ok {
+			cpuCost := vcpus * rates.CPUHourly
+			memoryCost := memoryGB * rates.MemoryGiBHourly
+			if cpuCost+memoryCost > 0 {
+				cpuShare = cpuCost / (cpuCost + memoryCost)
+			}
+		}
+		cpuPortion := node.ListPrice * cpuShare
+		memoryPortion := node.ListPrice - cpuPortion
+
+		vcpuCoverage := coverageRatio(u.committedVCPUs, u.vcpus)
+		memoryCoverage := coverageRatio(u.committedMemoryGB, u.memoryGB)
+		cudSavings := cpuPortion*vcpuCoverage*u.vcpuDiscount + memoryPortion*memoryCoverage*u.memoryDiscount
+
+		// Sustained-use discounts only apply to usage not covered by a commitment. The
+		// family's usage this month over its current size gives how much of the month
+		// its vCPUs and memory have run.
+		var vcpuFraction, memoryFraction float64
+		if sud := tracker.usage[sustainedUseKey{node.Region, family}]; sud != nil {
+			vcpuFraction = coverageRatio(sud.vcpuHours, u.vcpus*monthHours)
+			memoryFraction = coverageRatio(sud.memoryGBHours, u.memoryGB*monthHours)
+		}
+		var sudSavings float64
+		if !gcpConfig.DisableSustainedUseDiscounts {
+			sudSavings = cpuPortion*(1-vcpuCoverage)*(1-pricing.GCPSustainedUseMultiplier(family, vcpuFraction)) +
+				memoryPortion*(1-memoryCoverage)*(1-pricing.GCPSustainedUseMultiplier(family, memoryFraction))
+		}
+
+		result[i].HourlyPrice = node.ListPrice - cudSavings - sudSavings
+
+		summary.Nodes = append(summary.Nodes, NodeDiscount{
+			NodeName:            node.Name,
+			ListPrice:           node.ListPrice,
+			EffectivePrice:      result[i].HourlyPrice,
+			SustainedUseSavings: sudSavings,
+			CommittedUseSavings: cudSavings,
+			MonthFraction:       vcpuFraction,
+		})
+		summary.SustainedUseSavingsHourly += sudSavings
+		summary.CommittedUseSavingsHourly += cudSavings
+	}
+
+	return result, summary
+}
+
+// gcpBillingLocation is the time zone GCP billing months start in. Without time
+// zone data, Pacific Standard Time is used.
+var gcpBillingLocation = func() *time.Location {
+	location, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		return time.FixedZone("PST", -8*60*60)
+	}
+	return location
+}()
+
+// gcpBillingMonthStart returns the start of the GCP billing month containing now.
+// GCP resets sustained-use accounting at midnight US/Pacific.
+func gcpBillingMonthStart(now time.Time) time.Time {
+	local := now.In(gcpBillingLocation)
+	return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, gcpBillingLocation)
+}
+
+// coverageRatio returns the fraction of used capacity covered by a commitment
+func coverageRatio(committed, used float64) float64 {
+	if used <= 0 {
+		return 0
+	}
+	if committed >= used {
+		return 1
+	}
+	return committed / used
+}
@@ -0,0 +1,74 @@
+package calculator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+	"github.com/deepcost/kube-cost-exporter/pkg/config"
+)
+
+func TestGCPBillingMonthStart(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "first hours of the UTC month are still the previous Pacific month",
+			now:  time.Date(2026, 3, 1, 5, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 2, 1, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "after Pacific midnight",
+			now:  time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "daylight saving time",
+			now:  time.Date(2026, 7, 15, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 7, 1, 7, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gcpBillingMonthStart(tt.now); !got.Equal(tt.want) {
+				t.Errorf("gcpBillingMonthStart(%v) = %v, want %v", tt.now, got.UTC(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSustainedUseSurvivesNodeChurn(t *testing.T) {
+	cc := NewCostCalculator(Options{})
+	tracker := NewSustainedUseTracker()
+	monthStart := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	node := func(name string, created time.Time) collector.NodeInfo {
+		return collector.NodeInfo{
+			Name:           name,
+			CloudProvider:  "gcp",
+			InstanceType:   "n2-standard-4",
+			Region:         "us-central1",
+			CPUCapacity:    4000,
+			MemoryCapacity: 16 * 1024 * 1024 * 1024,
+			HourlyPrice:    0.2,
+			ListPrice:      0.2,
+			CreationTime:   created,
+		}
+	}
+
+	// One node for the first half of the month, replaced by another for the second
+	mid := monthStart.Add(15 * 24 * time.Hour)
+	end := monthStart.Add(30*24*time.Hour - time.Hour)
+	cc.ApplyGCPDiscounts([]collector.NodeInfo{node("a", monthStart)}, config.GCPConfig{}, tracker, mid)
+	_, summary := cc.ApplyGCPDiscounts([]collector.NodeInfo{node("b", mid)}, config.GCPConfig{}, tracker, end)
+
+	if len(summary.Nodes) != 1 {
+		t.Fatalf("got %d node discounts, want 1", len(summary.Nodes))
+	}
+	if got := summary.Nodes[0].MonthFraction; got < 0.95 {
+		t.Errorf("MonthFraction = %v after a full month of usage across two nodes, want close to 1", got)
+	}
+}
@@ -0,0 +1,201 @@
+package calculator
+
+import (
+	"sort"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+)
+
+// Idle distributions select how idle cost is reported in namespace costs
+const (
+	IdleSeparate     = "separate"     // idle cost is reported as the idle namespace
+	IdleProportional = "proportional" // idle cost is spread across namespaces by their cost
+)
+
+// IdleNamespace is the synthetic namespace idle cost is reported as
+const IdleNamespace = "__idle__"
+
+// ValidIdleDistribution reports whether distribution is a supported idle distribution
+func ValidIdleDistribution(distribution string) bool {
+	switch distribution {
+	case IdleSeparate, IdleProportional:
+		return true
+	}
+	return false
+}
+
+// IdleCost represents the part of a node, node pool or cluster's cost not allocated
+// to any pod
+type IdleCost struct {
+	Node                 string // empty for node pool and cluster totals
+	NodePool             string // empty for the cluster total
+	HourlyCost           float64
+	CPUCost              float64
+	MemoryCost           float64
+	GPUCost              float64
+	EphemeralStorageCost float64 // unallocated root volume capacity
+}
+
+// CalculateIdleCosts returns the idle cost of each node: the part of its cost,
+// including its root volume, not charged to pods or node overhead. Allocation
+// strategies can charge pods more or less than their allocations at unit rates,
+// so idle cost is what is left of the node's cost rather than its unallocated
+// capacity. It is split between CPU, memory, GPUs and ephemeral storage by their
+// unallocated capacity at the node's unit rates, or by their capacity if pods
+// allocate all of it.
+func (cc *CostCalculator) CalculateIdleCosts(nodes []collector.NodeInfo, podCosts []PodCost, overheadCosts []OverheadCost) []IdleCost {
+	type allocation struct {
+		cpu              int64
+		memory           int64
+		gpu              int64
+		ephemeralStorage int64
+		charged          float64 // hourly
+	}
+	allocations := make(map[string]*allocation)
+	allocationFor := func(node string) *allocation {
+		a, ok := allocations[node]
+		if !ok {
+			a = &allocation{}
+			allocations[node] = a
+		}
+		return a
+	}
+	for _, pod := range podCosts {
+		a := allocationFor(pod.NodeName)
+		a.cpu += pod.CPUAllocated
+		a.memory += pod.MemoryAllocated
+		a.gpu += pod.GPUAllocated
+		a.ephemeralStorage += pod.EphemeralStorageAllocated
+		a.charged += pod.HourlyCost
+	}
+	for _, overhead := range overheadCosts {
+		a := allocationFor(overhead.Node)
+		a.cpu += overhead.CPUAllocated
+		a.memory += overhead.MemoryAllocated
+		a.ephemeralStorage += overhead.EphemeralStorageAllocated
+		a.charged += overhead.HourlyCost
+	}
+
+	var idleCosts []IdleCost
+	for _, node := range nodes {
+		if node.CPUCapacity == 0 || node.MemoryCapacity == 0 {
+			continue
+		}
+
+		a := allocations[node.Name]
+		if a == nil {
+			a = &allocation{}
+		}
+
+		rates := cc.CalculateUnitRates(node)
+		cpuCapacity, memoryCapacity := cc.allocationCapacity(node)
+		ephemeralStorageCapacity := cc.ephemeralStorageCapacity(node)
+		weights := resourceIdleCosts(
+			max(cpuCapacity-a.cpu, 0),
+			max(memoryCapacity-a.memory, 0),
+			max(node.GPUCapacity-a.gpu, 0),
+			max(ephemeralStorageCapacity-a.ephemeralStorage, 0),
+			rates,
+		)
+		if weights.HourlyCost <= 0 {
+			weights = resourceIdleCosts(cpuCapacity, memoryCapacity, node.GPUCapacity, ephemeralStorageCapacity, rates)
+		}
+
+		cost := IdleCost{
+			Node:       node.Name,
+			NodePool:   node.NodePool,
+			HourlyCost: max(node.TotalHourlyCost()-a.charged, 0),
+		}
+		if weights.HourlyCost > 0 {
+			scale := cost.HourlyCost / weights.HourlyCost
+			cost.CPUCost = weights.CPUCost * scale
+			cost.MemoryCost = weights.MemoryCost * scale
+			cost.GPUCost = weights.GPUCost * scale
+			cost.EphemeralStorageCost = weights.EphemeralStorageCost * scale
+		}
+		idleCosts = append(idleCosts, cost)
+	}
+
+	return idleCosts
+}
+
+// resourceIdleCosts prices CPU (millicores), memory and ephemeral storage (bytes)
+// and GPUs at the unit rates
+func resourceIdleCosts(cpu, memory, gpus, ephemeralStorage int64, rates UnitRates) IdleCost {
+	costs := rateCosts(cpu, memory, gpus, rates)
+	cost := IdleCost{
+		CPUCost:              costs.CPU,
+		MemoryCost:           costs.Memory,
+		GPUCost:              costs.GPU,
+		EphemeralStorageCost: float64(ephemeralStorage) / (1024 * 1024 * 1024) * rates.EphemeralStorageGiBHourly,
+	}
+	cost.HourlyCost = cost.CPUCost + cost.MemoryCost + cost.GPUCost + cost.EphemeralStorageCost
+	return cost
+}
+
+// CalculateNodePoolIdleCosts aggregates node idle costs by node pool
+func (cc *CostCalculator) CalculateNodePoolIdleCosts(idleCosts []IdleCost) []IdleCost {
+	poolMap := make(map[string]*IdleCost)
+	for _, idle := range idleCosts {
+		pool, ok := poolMap[idle.NodePool]
+		if !ok {
+			pool = &IdleCost{NodePool: idle.NodePool}
+			poolMap[idle.NodePool] = pool
+		}
+		pool.HourlyCost += idle.HourlyCost
+		pool.CPUCost += idle.CPUCost
+		pool.MemoryCost += idle.MemoryCost
+		pool.GPUCost += idle.GPUCost
+		pool.EphemeralStorageCost += idle.EphemeralStorageCost
+	}
+
+	var pools []IdleCost
+	for _, pool := range poolMap {
+		pools = append(pools, *pool)
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].NodePool < pools[j].NodePool })
+	return pools
+}
+
+// CalculateClusterIdleCost sums node idle costs
+func (cc *CostCalculator) CalculateClusterIdleCost(idleCosts []IdleCost) IdleCost {
+	var total IdleCost
+	for _, idle := range idleCosts {
+		total.HourlyCost += idle.HourlyCost
+		total.CPUCost += idle.CPUCost
+		total.MemoryCost += idle.MemoryCost
+		total.GPUCost += idle.GPUCost
+		total.EphemeralStorageCost += idle.EphemeralStorageCost
+	}
+	return total
+}
+
+// DistributeIdleCost adds the cluster idle cost to namespace costs, either as the
+// idle namespace or spread across namespaces in proportion to their cost
+func (cc *CostCalculator) DistributeIdleCost(namespaceCosts []NamespaceCost, clusterIdle IdleCost) []NamespaceCost {
+	if clusterIdle.HourlyCost <= 0 {
+		return namespaceCosts
+	}
+
+	var totalCost float64
+	for _, ns := range namespaceCosts {
+		totalCost += ns.HourlyCost
+	}
+
+	if cc.opts.IdleDistribution != IdleProportional || totalCost <= 0 {
+		return append(namespaceCosts, NamespaceCost{
+			Namespace:   IdleNamespace,
+			HourlyCost:  clusterIdle.HourlyCost,
+			DailyCost:   clusterIdle.HourlyCost * 24,
+			MonthlyCost: clusterIdle.HourlyCost * 730,
+		})
+	}
+
+	for i := range namespaceCosts {
+		share := clusterIdle.HourlyCost * namespaceCosts[i].HourlyCost / totalCost
+		namespaceCosts[i].HourlyCost += share
+		namespaceCosts[i].DailyCost += share * 24
+		namespaceCosts[i].MonthlyCost += share * 730
+	}
+	return namespaceCosts
+}
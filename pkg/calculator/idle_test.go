@@ -0,0 +1,75 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+)
+
+func TestIdleCostAddsUpToClusterCost(t *testing.T) {
+	node := testNode("node-1")
+	node.CPUAllocatable = 3800
+	node.MemoryAllocatable = 15 * gib
+	node.RootVolumeType = "gp3"
+	node.RootVolumeSizeGB = 100
+	node.RootVolumePricePerGBMonth = 0.08
+	node.EphemeralStorageCapacity = 100 * gib
+	node.EphemeralStorageAllocatable = 90 * gib
+
+	pods := []collector.PodInfo{
+		{
+			Name: "web", Namespace: "shop", NodeName: node.Name,
+			CPURequest: 1000, MemoryRequest: 2 * gib, EphemeralStorageRequest: 10 * gib,
+			CPUUsage: 500, MemoryUsage: 1 * gib, HasUsage: true,
+		},
+		{
+			Name: "db", Namespace: "data", NodeName: node.Name,
+			CPURequest: 500, MemoryRequest: 8 * gib,
+		},
+	}
+
+	strategies := []string{StrategyUnitRate, StrategyDominantResource, StrategyWeighted, StrategyUsage, StrategyEvenSplit}
+	policies := []string{OverheadIgnore, OverheadProportional, OverheadSystem}
+	for _, strategy := range strategies {
+		for _, policy := range policies {
+			t.Run(strategy+"/"+policy, func(t *testing.T) {
+				cc := NewCostCalculator(Options{Strategy: strategy, OverheadPolicy: policy})
+				nodes := []collector.NodeInfo{node}
+
+				podCosts := cc.CalculatePodCosts(pods, nodes)
+				overheadCosts := cc.CalculateOverheadCosts(nodes)
+				idle := cc.CalculateClusterIdleCost(cc.CalculateIdleCosts(nodes, podCosts, overheadCosts))
+				namespaceCosts := cc.AddOverheadNamespaceCosts(cc.CalculateNamespaceCosts(podCosts), overheadCosts)
+				namespaceCosts = cc.DistributeIdleCost(namespaceCosts, idle)
+
+				var sum float64
+				for _, ns := range namespaceCosts {
+					sum += ns.HourlyCost
+				}
+				if total := cc.CalculateTotalClusterCost(nodes); !approxEqual(sum, total) {
+					t.Errorf("namespace and idle costs = %v, want cluster cost %v", sum, total)
+				}
+
+				breakdown := idle.CPUCost + idle.MemoryCost + idle.GPUCost + idle.EphemeralStorageCost
+				if !approxEqual(breakdown, idle.HourlyCost) {
+					t.Errorf("idle resource costs = %v, want idle cost %v", breakdown, idle.HourlyCost)
+				}
+			})
+		}
+	}
+}
+
+func TestEvenSplitLeavesOnlyRootVolumeIdle(t *testing.T) {
+	node := testNode("node-1")
+	cc := NewCostCalculator(Options{Strategy: StrategyEvenSplit})
+	pods := []collector.PodInfo{
+		{Name: "a", Namespace: "ns", NodeName: node.Name},
+		{Name: "b", Namespace: "ns", NodeName: node.Name},
+	}
+
+	podCosts := cc.CalculatePodCosts(pods, []collector.NodeInfo{node})
+	idle := cc.CalculateIdleCosts([]collector.NodeInfo{node}, podCosts, nil)
+	if len(idle) != 1 || !approxEqual(idle[0].HourlyCost, 0) {
+		t.Errorf("idle costs = %+v, want no idle cost when pods split the whole node", idle)
+	}
+}
@@ -0,0 +1,82 @@
+package calculator
+
+import (
+	"sort"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+)
+
+// NodePoolCost represents the aggregated cost, capacity and allocation of a node
+// pool (node group, agent pool, Karpenter NodePool)
+type NodePoolCost struct {
+	NodePool       string
+	NodeCount      int
+	SpotNodeCount  int
+	HourlyCost     float64
+	SpotHourlyCost float64
+	IdleCost       float64
+
+	// Capacity of the pool's nodes and the resources allocated to pods on them
+	CPUCapacity     int64 // millicores
+	MemoryCapacity  int64 // bytes
+	GPUCapacity     int64
+	CPUAllocated    int64 // millicores
+	MemoryAllocated int64 // bytes
+	GPUAllocated    int64
+}
+
+// SpotShare returns the fraction of the pool's cost on spot nodes
+func (p NodePoolCost) SpotShare() float64 {
+	if p.HourlyCost <= 0 {
+		return 0
+	}
+	return p.SpotHourlyCost / p.HourlyCost
+}
+
+// CalculateNodePoolCosts aggregates node costs, capacity, pod allocations and
+// idle costs by node pool
+func (cc *CostCalculator) CalculateNodePoolCosts(nodes []collector.NodeInfo, podCosts []PodCost, idleCosts []IdleCost) []NodePoolCost {
+	nodePools := make(map[string]string, len(nodes)) // node pool by node name
+	poolMap := make(map[string]*NodePoolCost)
+	for _, node := range nodes {
+		nodePools[node.Name] = node.NodePool
+		pool, ok := poolMap[node.NodePool]
+		if !ok {
+			pool = &NodePoolCost{NodePool: node.NodePool}
+			poolMap[node.NodePool] = pool
+		}
+
+		pool.NodeCount++
+		pool.HourlyCost += node.TotalHourlyCost()
+		if node.IsSpot {
+			pool.SpotNodeCount++
+			pool.SpotHourlyCost += node.TotalHourlyCost()
+		}
+		pool.CPUCapacity += node.CPUCapacity
+		pool.MemoryCapacity += node.MemoryCapacity
+		pool.GPUCapacity += node.GPUCapacity
+	}
+
+	for _, podCost := range podCosts {
+		pool, ok := poolMap[nodePools[podCost.NodeName]]
+		if !ok {
+			continue
+		}
+		pool.CPUAllocated += podCost.CPUAllocated
+		pool.MemoryAllocated += podCost.MemoryAllocated
+		pool.GPUAllocated += podCost.GPUAllocated
+	}
+
+	for _, idle := range idleCosts {
+		if pool, ok := poolMap[idle.NodePool]; ok {
+			pool.IdleCost += idle.HourlyCost
+		}
+	}
+
+	var pools []NodePoolCost
+	for _, pool := range poolMap {
+		pools = append(pools, *pool)
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].NodePool < pools[j].NodePool })
+	return pools
+}
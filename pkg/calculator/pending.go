@@ -0,0 +1,167 @@
+package calculator
+
+import (
+	"sort"
+	"time"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+)
+
+// PendingPodCost is the would-be cost of a pod that is not scheduled yet: its
+// requests priced on the cheapest node shape it fits and is eligible for
+type PendingPodCost struct {
+	Namespace       string
+	PodName         string
+	OwnerKind       string
+	OwnerName       string
+	NodePool        string // cheapest fitting shape, empty if no node shape fits
+	InstanceType    string
+	IsSpot          bool
+	HourlyCost      float64
+	PendingDuration time.Duration
+}
+
+// NamespacePendingCost is the would-be cost of a namespace's pending pods
+type NamespacePendingCost struct {
+	Namespace     string
+	HourlyCost    float64
+	PodCount      int
+	Unschedulable int // pods that fit no node shape
+}
+
+// nodeShape is an instance type in a node pool, priced by one of its nodes. Nodes
+// of a shape can have different labels and taints, so all of them are kept for
+// eligibility checks.
+type nodeShape struct {
+	node           collector.NodeInfo
+	nodes          []collector.NodeInfo
+	rates          UnitRates
+	cpuCapacity    int64
+	memoryCapacity int64
+}
+
+// eligible reports whether a pod's node selector, affinity and tolerations allow
+// any node of the shape
+func (s nodeShape) eligible(pod collector.PodInfo) bool {
+	for _, node := range s.nodes {
+		if collector.PodEligibleForNode(pod, node) {
+			return true
+		}
+	}
+	return false
+}
+
+// CalculatePendingPodCosts prices pods that are not scheduled to a node. Each
+// pod's requests are priced at the unit rates of the cheapest node shape whose
+// allocatable resources fit them and whose labels and taints the pod's node
+// selector, affinity and tolerations allow.
+func (cc *CostCalculator) CalculatePendingPodCosts(pods []collector.PodInfo, nodes []collector.NodeInfo, now time.Time) []PendingPodCost {
+	shapes := cc.nodeShapes(nodes)
+
+	var pendingCosts []PendingPodCost
+	for _, pod := range pods {
+		if pod.NodeName != "" {
+			continue
+		}
+
+		cost := PendingPodCost{
+			Namespace: pod.Namespace,
+			PodName:   pod.Name,
+			OwnerKind: pod.OwnerKind,
+			OwnerName: pod.OwnerName,
+		}
+		if !pod.CreationTime.IsZero() {
+			cost.PendingDuration = now.Sub(pod.CreationTime)
+		}
+
+		found := false
+		for _, shape := range shapes {
+			if pod.CPURequest > shape.cpuCapacity || pod.MemoryRequest > shape.memoryCapacity || pod.GPURequest > shape.node.GPUCapacity {
+				continue
+			}
+			if !shape.eligible(pod) {
+				continue
+			}
+
+			costs := rateCosts(pod.CPURequest, pod.MemoryRequest, pod.GPURequest, shape.rates)
+			hourlyCost := costs.CPU + costs.Memory + costs.GPU
+			if !found || hourlyCost < cost.HourlyCost {
+				cost.NodePool = shape.node.NodePool
+				cost.InstanceType = shape.node.InstanceType
+				cost.IsSpot = shape.node.IsSpot
+				cost.HourlyCost = hourlyCost
+				found = true
+			}
+		}
+		if !found {
+			cc.logger.Debugf("No node shape fits pending pod %s/%s", pod.Namespace, pod.Name)
+		}
+
+		pendingCosts = append(pendingCosts, cost)
+	}
+
+	return pendingCosts
+}
+
+// CalculateNamespacePendingCosts aggregates pending pod costs by namespace
+func (cc *CostCalculator) CalculateNamespacePendingCosts(pendingCosts []PendingPodCost) []NamespacePendingCost {
+	nsMap := make(map[string]*NamespacePendingCost)
+	for _, pending := range pendingCosts {
+		ns, ok := nsMap[pending.Namespace]
+		if !ok {
+			ns = &NamespacePendingCost{Namespace: pending.Namespace}
+			nsMap[pending.Namespace] = ns
+		}
+		ns.HourlyCost += pending.HourlyCost
+		ns.PodCount++
+		if pending.InstanceType == "" {
+			ns.Unschedulable++
+		}
+	}
+
+	var namespaces []NamespacePendingCost
+	for _, ns := range nsMap {
+		namespaces = append(namespaces, *ns)
+	}
+	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].Namespace < namespaces[j].Namespace })
+	return namespaces
+}
+
+// nodeShapes groups priced nodes by node pool, instance type and capacity type.
+// Nodes without a price are skipped.
+func (cc *CostCalculator) nodeShapes(nodes []collector.NodeInfo) []nodeShape {
+	type shapeKey struct {
+		nodePool     string
+		instanceType string
+		isSpot       bool
+	}
+
+	index := make(map[shapeKey]int)
+	var shapes []nodeShape
+	for _, node := range nodes {
+		if node.HourlyPrice <= 0 || node.CPUCapacity == 0 || node.MemoryCapacity == 0 {
+			continue
+		}
+		key := shapeKey{node.NodePool, node.InstanceType, node.IsSpot}
+		if i, ok := index[key]; ok {
+			shapes[i].nodes = append(shapes[i].nodes, node)
+			continue
+		}
+		index[key] = len(shapes)
+
+		// Pods are scheduled against allocatable resources
+		cpuCapacity, memoryCapacity := node.CPUAllocatable, node.MemoryAllocatable
+		if cpuCapacity == 0 || memoryCapacity == 0 {
+			cpuCapacity, memoryCapacity = node.CPUCapacity, node.MemoryCapacity
+		}
+
+		shapes = append(shapes, nodeShape{
+			node:           node,
+			nodes:          []collector.NodeInfo{node},
+			rates:          cc.CalculateUnitRates(node),
+			cpuCapacity:    cpuCapacity,
+			memoryCapacity: memoryCapacity,
+		})
+	}
+	return shapes
+}
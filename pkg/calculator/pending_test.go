@@ -0,0 +1,84 @@
+package calculator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCalculatePendingPodCosts(t *testing.T) {
+	onDemand := testNode("on-demand-1")
+	onDemand.Labels = map[string]string{"zone": "a"}
+
+	// Nodes of the spot shape differ in labels and taints
+	spot1 := testNode("spot-1")
+	spot1.NodePool, spot1.IsSpot, spot1.HourlyPrice = "spot", true, 0.06
+	spot1.Labels = map[string]string{"zone": "a"}
+	spot1.Taints = []corev1.Taint{{Key: "spot", Value: "true", Effect: corev1.TaintEffectNoSchedule}}
+	spot2 := spot1
+	spot2.Name = "spot-2"
+	spot2.Labels = map[string]string{"zone": "b"}
+	spot2.Taints = nil
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	request := func(name string) collector.PodInfo {
+		return collector.PodInfo{
+			Name: name, Namespace: "shop", CPURequest: 1000, MemoryRequest: 4 * gib,
+			CreationTime: now.Add(-10 * time.Minute),
+		}
+	}
+	web := request("web")
+	web.NodeSelector = map[string]string{"zone": "a"}
+	batch := request("batch")
+	batch.NodeSelector = map[string]string{"zone": "a"}
+	batch.Tolerations = []corev1.Toleration{{Key: "spot", Operator: corev1.TolerationOpExists}}
+	api := request("api")
+	api.NodeSelector = map[string]string{"zone": "b"}
+	huge := request("huge")
+	huge.CPURequest = 64000
+	running := request("running")
+	running.NodeName = onDemand.Name
+
+	cc := NewCostCalculator(Options{})
+	pending := cc.CalculatePendingPodCosts([]collector.PodInfo{web, batch, api, huge, running}, []collector.NodeInfo{onDemand, spot1, spot2}, now)
+	if len(pending) != 4 {
+		t.Fatalf("got %d pending pods, want 4", len(pending))
+	}
+
+	byName := make(map[string]PendingPodCost)
+	for _, cost := range pending {
+		byName[cost.PodName] = cost
+	}
+	wantPools := map[string]string{"web": "default", "batch": "spot", "api": "spot", "huge": ""}
+	for name, pool := range wantPools {
+		if got := byName[name].NodePool; got != pool {
+			t.Errorf("%s node pool = %q, want %q", name, got, pool)
+		}
+	}
+
+	onDemandCost := byName["web"].HourlyCost
+	if onDemandCost <= 0 {
+		t.Fatalf("web cost = %v, want a cost", onDemandCost)
+	}
+	for _, name := range []string{"batch", "api"} {
+		if got := byName[name].HourlyCost; !approxEqual(got, onDemandCost*0.3) || !byName[name].IsSpot {
+			t.Errorf("%s cost = %v (spot %v), want spot price %v", name, got, byName[name].IsSpot, onDemandCost*0.3)
+		}
+	}
+	if byName["huge"].HourlyCost != 0 || byName["huge"].InstanceType != "" {
+		t.Errorf("huge = %+v, want unpriced", byName["huge"])
+	}
+	if byName["web"].PendingDuration != 10*time.Minute {
+		t.Errorf("pending duration = %v, want 10m", byName["web"].PendingDuration)
+	}
+
+	namespaces := cc.CalculateNamespacePendingCosts(pending)
+	if len(namespaces) != 1 || namespaces[0].PodCount != 4 || namespaces[0].Unschedulable != 1 {
+		t.Errorf("namespace pending costs = %+v, want 4 pods with 1 unschedulable", namespaces)
+	}
+	if want := onDemandCost * 1.6; !approxEqual(namespaces[0].HourlyCost, want) {
+		t.Errorf("namespace pending cost = %v, want %v", namespaces[0].HourlyCost, want)
+	}
+}
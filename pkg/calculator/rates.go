@@ -0,0 +1,75 @@
+package calculator
+
+import (
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+)
+
+// DefaultCPURAMCostRatio is the default hourly cost of a CPU core relative to a
+// GiB of memory, from typical general purpose instance pricing
+const DefaultCPURAMCostRatio = 7.5
+
+// defaultGPUCPUCostRatio is the hourly cost of a GPU relative to a CPU core, used
+// for nodes whose provider has no component rates
+const defaultGPUCPUCostRatio = 16.0
+
+// UnitRates are a node's hourly prices per unit of each resource. The CPU, memory
+// and GPU rates are scaled so that the node's full allocation capacity adds up to
+// its price, and the ephemeral storage rate so that it adds up to the cost of its
+// root volume.
+type UnitRates struct {
+	CPUCoreHourly             float64
+	MemoryGiBHourly           float64
+	GPUHourly                 float64
+	EphemeralStorageGiBHourly float64
+}
+
+// CalculateUnitRates splits a node's price into CPU, memory and GPU unit rates. The
+// provider's component rates set the relative weight of each resource, falling back
+// to the configured CPU to RAM cost ratio. Ephemeral storage is priced by the
+// node's root volume.
+func (cc *CostCalculator) CalculateUnitRates(node collector.NodeInfo) UnitRates {
+	cpuCapacity, memoryCapacity := cc.allocationCapacity(node)
+	cores := float64(cpuCapacity) / 1000
+	memoryGiB := float64(memoryCapacity) / (1024 * 1024 * 1024)
+	gpus := float64(node.GPUCapacity)
+
+	weights := UnitRates{
+		CPUCoreHourly:   cc.opts.CPURAMCostRatio,
+		MemoryGiBHourly: 1,
+		GPUHourly:       cc.opts.CPURAMCostRatio * defaultGPUCPUCostRatio,
+	}
+	if rates := node.ComponentRates; rates.CPUHourly > 0 && rates.MemoryGiBHourly > 0 {
+		weights = UnitRates{
+			CPUCoreHourly:   rates.CPUHourly,
+			MemoryGiBHourly: rates.MemoryGiBHourly,
+			GPUHourly:       rates.GPUHourly,
+		}
+		if weights.GPUHourly == 0 {
+			weights.GPUHourly = rates.CPUHourly * defaultGPUCPUCostRatio
+		}
+	}
+
+	rates := UnitRates{EphemeralStorageGiBHourly: cc.ephemeralStorageRate(node)}
+	total := weights.CPUCoreHourly*cores + weights.MemoryGiBHourly*memoryGiB + weights.GPUHourly*gpus
+	if total <= 0 {
+		return rates
+	}
+
+	scale := node.HourlyPrice / total
+	rates.CPUCoreHourly = weights.CPUCoreHourly * scale
+	rates.MemoryGiBHourly = weights.MemoryGiBHourly * scale
+	rates.GPUHourly = weights.GPUHourly * scale
+	return rates
+}
+
+// ephemeralStorageRate returns the hourly price of a GiB of a node's ephemeral
+// storage: its root volume cost spread over the ephemeral storage pods are
+// allocated from. Nodes that do not report ephemeral storage are charged the root
+// volume price.
+func (cc *CostCalculator) ephemeralStorageRate(node collector.NodeInfo) float64 {
+	capacity := cc.ephemeralStorageCapacity(node)
+	if capacity <= 0 || node.RootVolumeSizeGB <= 0 {
+		return node.RootVolumePricePerGBMonth / 730
+	}
+	return node.RootVolumeHourlyCost() / (float64(capacity) / (1024 * 1024 * 1024))
+}
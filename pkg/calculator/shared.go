@@ -0,0 +1,183 @@
+package calculator
+
+import (
+	"sort"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/config"
+)
+
+// Shared cost distributions select how a shared cost rule splits the cost between
+// its target namespaces
+const (
+	SharedEven         = "even"         // an equal share per target
+	SharedProportional = "proportional" // in proportion to the targets' cost
+	SharedWeighted     = "weighted"     // in proportion to the configured weights
+)
+
+// SharedCost is the part of a shared cost rule's cost distributed to a namespace
+type SharedCost struct {
+	Rule       string
+	Namespace  string
+	HourlyCost float64
+}
+
+// DistributeSharedCosts applies shared cost rules to namespace costs in order. The
+// cost of each rule's source namespaces and of pods with its source labels is
+// moved to its target namespaces. A pod matching the labels of several rules is
+// shared by the first. Source namespaces are kept with the cost they have left.
+// The input namespace costs are not modified.
+func (cc *CostCalculator) DistributeSharedCosts(namespaceCosts []NamespaceCost, podCosts []PodCost, rules []config.SharedCostRule) ([]NamespaceCost, []SharedCost) {
+	distributed := make([]NamespaceCost, len(namespaceCosts))
+	copy(distributed, namespaceCosts)
+	if len(rules) == 0 {
+		return distributed, nil
+	}
+
+	index := make(map[string]int, len(distributed))
+	for i, ns := range distributed {
+		index[ns.Namespace] = i
+	}
+	namespace := func(name string) *NamespaceCost {
+		i, ok := index[name]
+		if !ok {
+			i = len(distributed)
+			index[name] = i
+			distributed = append(distributed, NamespaceCost{Namespace: name})
+		}
+		return &distributed[i]
+	}
+
+	// Namespaces shared by any rule never receive shared costs by default, and
+	// their pods are only shared through their namespace
+	sharedNamespaces := make(map[string]bool)
+	for _, rule := range rules {
+		for _, name := range rule.Source.Namespaces {
+			sharedNamespaces[name] = true
+		}
+	}
+
+	claimed := make(map[int]bool) // pods shared by label, by index
+	var shares []SharedCost
+	for _, rule := range rules {
+		// Collect what each namespace contributes to the rule
+		sources := make(map[string]float64)
+		var matched []int
+		for _, name := range rule.Source.Namespaces {
+			if i, ok := index[name]; ok && distributed[i].HourlyCost > 0 {
+				sources[name] = distributed[i].HourlyCost
+			}
+		}
+		if len(rule.Source.Labels) > 0 {
+			for i, pod := range podCosts {
+				if claimed[i] || sharedNamespaces[pod.Namespace] || !matchesLabels(pod.Labels, rule.Source.Labels) {
+					continue
+				}
+				sources[pod.Namespace] += pod.HourlyCost
+				matched = append(matched, i)
+			}
+		}
+
+		var total float64
+		for _, cost := range sources {
+			total += cost
+		}
+
+		targets := sharedCostTargets(rule, distributed, sharedNamespaces)
+		weights := cc.sharedCostWeights(rule, targets, distributed, index)
+		if total <= 0 || weights == nil {
+			continue
+		}
+
+		for _, i := range matched {
+			claimed[i] = true
+		}
+		for name, cost := range sources {
+			addNamespaceCost(namespace(name), -cost)
+		}
+		for i, name := range targets {
+			share := total * weights[i]
+			addNamespaceCost(namespace(name), share)
+			shares = append(shares, SharedCost{Rule: rule.Name, Namespace: name, HourlyCost: share})
+		}
+	}
+
+	return distributed, shares
+}
+
+// sharedCostTargets returns the target namespaces of a rule, sorted
+func sharedCostTargets(rule config.SharedCostRule, namespaceCosts []NamespaceCost, sharedNamespaces map[string]bool) []string {
+	targets := make(map[string]bool)
+	if len(rule.Targets) > 0 {
+		for _, name := range rule.Targets {
+			targets[name] = true
+		}
+	} else {
+		for _, ns := range namespaceCosts {
+			if sharedNamespaces[ns.Namespace] || ns.Namespace == IdleNamespace || ns.Namespace == SystemNamespace {
+				continue
+			}
+			targets[ns.Namespace] = true
+		}
+	}
+
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sharedCostWeights returns each target's fraction of a rule's cost, or nil if
+// there are no targets. Proportional and weighted rules whose targets have no
+// cost or weight fall back to an even split.
+func (cc *CostCalculator) sharedCostWeights(rule config.SharedCostRule, targets []string, namespaceCosts []NamespaceCost, index map[string]int) []float64 {
+	if len(targets) == 0 {
+		return nil
+	}
+
+	weights := make([]float64, len(targets))
+	var sum float64
+	for i, name := range targets {
+		switch rule.Distribution {
+		case SharedEven:
+			weights[i] = 1
+		case SharedWeighted:
+			weights[i] = rule.Weights[name]
+		default:
+			if j, ok := index[name]; ok {
+				weights[i] = max(namespaceCosts[j].HourlyCost, 0)
+			}
+		}
+		sum += weights[i]
+	}
+
+	if sum <= 0 {
+		cc.logger.Debugf("Shared cost rule %s has no target cost or weight, splitting evenly", rule.Name)
+		for i := range weights {
+			weights[i] = 1
+		}
+		sum = float64(len(weights))
+	}
+	for i := range weights {
+		weights[i] /= sum
+	}
+	return weights
+}
+
+// addNamespaceCost adds an hourly cost to a namespace's hourly, daily and monthly costs
+func addNamespaceCost(ns *NamespaceCost, hourlyCost float64) {
+	ns.HourlyCost += hourlyCost
+	ns.DailyCost += hourlyCost * 24
+	ns.MonthlyCost += hourlyCost * 730
+}
+
+// matchesLabels reports whether labels contain all the selector's labels
+func matchesLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
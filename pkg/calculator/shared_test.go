@@ -0,0 +1,92 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/config"
+)
+
+func TestDistributeSharedCosts(t *testing.T) {
+	namespaceCosts := []NamespaceCost{
+		{Namespace: "monitoring", HourlyCost: 3},
+		{Namespace: "shop", HourlyCost: 6},
+		{Namespace: "blog", HourlyCost: 3},
+	}
+	podCosts := []PodCost{
+		{PodName: "shop-proxy", Namespace: "shop", HourlyCost: 1, Labels: map[string]string{"tier": "ingress", "team": "platform"}},
+		{PodName: "blog-web", Namespace: "blog", HourlyCost: 2, Labels: map[string]string{"team": "blog"}},
+	}
+	monitoring := config.SharedCostSource{Namespaces: []string{"monitoring"}}
+
+	tests := []struct {
+		name  string
+		rules []config.SharedCostRule
+		want  map[string]float64
+	}{
+		{
+			name:  "proportional to all other namespaces",
+			rules: []config.SharedCostRule{{Name: "monitoring", Source: monitoring}},
+			want:  map[string]float64{"monitoring": 0, "shop": 8, "blog": 4},
+		},
+		{
+			name:  "even to targets",
+			rules: []config.SharedCostRule{{Name: "monitoring", Source: monitoring, Targets: []string{"shop", "blog", "search"}, Distribution: SharedEven}},
+			want:  map[string]float64{"monitoring": 0, "shop": 7, "blog": 4, "search": 1},
+		},
+		{
+			name: "weighted",
+			rules: []config.SharedCostRule{{
+				Name: "monitoring", Source: monitoring, Targets: []string{"shop", "blog"},
+				Distribution: SharedWeighted, Weights: map[string]float64{"shop": 2, "blog": 1},
+			}},
+			want: map[string]float64{"monitoring": 0, "shop": 8, "blog": 4},
+		},
+		{
+			name: "weighted without weights splits evenly",
+			rules: []config.SharedCostRule{{
+				Name: "monitoring", Source: monitoring, Targets: []string{"shop", "blog"}, Distribution: SharedWeighted,
+			}},
+			want: map[string]float64{"monitoring": 0, "shop": 7.5, "blog": 4.5},
+		},
+		{
+			name: "pods by label are shared by the first matching rule",
+			rules: []config.SharedCostRule{
+				{Name: "ingress", Source: config.SharedCostSource{Labels: map[string]string{"tier": "ingress"}}, Targets: []string{"blog"}},
+				{Name: "platform", Source: config.SharedCostSource{Labels: map[string]string{"team": "platform"}}, Targets: []string{"monitoring"}},
+			},
+			want: map[string]float64{"monitoring": 3, "shop": 5, "blog": 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := NewCostCalculator(Options{})
+			distributed, shares := cc.DistributeSharedCosts(namespaceCosts, podCosts, tt.rules)
+
+			var total float64
+			for _, ns := range distributed {
+				total += ns.HourlyCost
+				if want, ok := tt.want[ns.Namespace]; !ok || !approxEqual(ns.HourlyCost, want) {
+					t.Errorf("namespace %s cost = %v, want %v", ns.Namespace, ns.HourlyCost, want)
+				}
+			}
+			if len(distributed) != len(tt.want) {
+				t.Errorf("got %d namespaces, want %d", len(distributed), len(tt.want))
+			}
+			if !approxEqual(total, 12) {
+				t.Errorf("total cost = %v, want 12", total)
+			}
+
+			var shared float64
+			for _, share := range shares {
+				shared += share.HourlyCost
+			}
+			if shared <= 0 {
+				t.Errorf("shares = %+v, want shared cost", shares)
+			}
+
+			if namespaceCosts[0].HourlyCost != 3 || namespaceCosts[1].HourlyCost != 6 {
+				t.Errorf("input namespace costs modified: %+v", namespaceCosts)
+			}
+		})
+	}
+}
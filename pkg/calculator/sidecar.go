@@ -0,0 +1,119 @@
+package calculator
+
+import (
+	"sort"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/config"
+)
+
+// SidecarNamespace is the synthetic namespace sidecar costs are charged to when
+// their rule has no namespace
+const SidecarNamespace = "__sidecar__"
+
+// SidecarCost is the cost of a sidecar container in the pods of a namespace,
+// carved out of their cost
+type SidecarCost struct {
+	Container        string
+	Owner            string
+	Namespace        string // namespace of the pods the sidecar runs in
+	ChargedNamespace string // namespace the cost is charged to
+	HourlyCost       float64
+	PodCount         int
+}
+
+// CarveOutSidecarCosts removes the cost of the containers named by sidecar rules
+// from each pod's cost. Each sidecar's share is of the pod's compute cost before
+// any sidecar is carved out, and the carved containers' costs are zeroed. Pods keep
+// their allocations, so idle cost is unchanged. Returns the pod costs and the carved out cost of each sidecar
+// by the namespace it runs in, which AddSidecarNamespaceCosts charges to the
+// rule's namespace.
+func (cc *CostCalculator) CarveOutSidecarCosts(podCosts []PodCost, rules []config.SidecarRule) ([]PodCost, []SidecarCost) {
+	if len(rules) == 0 {
+		return podCosts, nil
+	}
+
+	ruleFor := make(map[string]config.SidecarRule) // by container name
+	for _, rule := range rules {
+		for _, container := range rule.Containers {
+			ruleFor[container] = rule
+		}
+	}
+
+	type sidecarKey struct{ container, namespace string }
+	sidecarMap := make(map[sidecarKey]*SidecarCost)
+
+	for i := range podCosts {
+		podCost := &podCosts[i]
+		original := *podCost
+		computeCost := original.CPUCost + original.MemoryCost + original.GPUCost
+		for j := range podCost.Containers {
+			container := &podCost.Containers[j]
+			rule, ok := ruleFor[container.Name]
+			if !ok || container.HourlyCost <= 0 || computeCost <= 0 {
+				continue
+			}
+			carved := sidecarComputeCost(original, container.HourlyCost/computeCost)
+			removeComputeCost(podCost, carved)
+			container.HourlyCost = 0
+
+			sidecar, ok := sidecarMap[sidecarKey{container.Name, podCost.Namespace}]
+			if !ok {
+				chargedNamespace := rule.Namespace
+				if chargedNamespace == "" {
+					chargedNamespace = SidecarNamespace
+				}
+				sidecar = &SidecarCost{
+					Container:        container.Name,
+					Owner:            rule.Owner,
+					Namespace:        podCost.Namespace,
+					ChargedNamespace: chargedNamespace,
+				}
+				sidecarMap[sidecarKey{container.Name, podCost.Namespace}] = sidecar
+			}
+			sidecar.HourlyCost += carved.CPU + carved.Memory + carved.GPU
+			sidecar.PodCount++
+		}
+	}
+
+	sidecars := make([]SidecarCost, 0, len(sidecarMap))
+	for _, sidecar := range sidecarMap {
+		sidecars = append(sidecars, *sidecar)
+	}
+	sort.Slice(sidecars, func(i, j int) bool {
+		if sidecars[i].Container != sidecars[j].Container {
+			return sidecars[i].Container < sidecars[j].Container
+		}
+		return sidecars[i].Namespace < sidecars[j].Namespace
+	})
+
+	return podCosts, sidecars
+}
+
+// sidecarComputeCost returns a share of a pod's CPU, memory and GPU costs
+func sidecarComputeCost(podCost PodCost, share float64) ResourceCosts {
+	return ResourceCosts{
+		CPU:    podCost.CPUCost * share,
+		Memory: podCost.MemoryCost * share,
+		GPU:    podCost.GPUCost * share,
+	}
+}
+
+// removeComputeCost subtracts compute costs from a pod's cost
+func removeComputeCost(podCost *PodCost, costs ResourceCosts) {
+	podCost.CPUCost -= costs.CPU
+	podCost.MemoryCost -= costs.Memory
+	podCost.GPUCost -= costs.GPU
+	podCost.HourlyCost -= costs.CPU + costs.Memory + costs.GPU
+	podCost.DailyCost = podCost.HourlyCost * 24
+	podCost.MonthlyCost = podCost.HourlyCost * 730
+}
+
+// AddSidecarNamespaceCosts charges the carved out cost of sidecars to the
+// namespaces of their rules. Sidecar costs are charged at the namespace level only,
+// so they are not part of pod, workload or dimension costs.
+func (cc *CostCalculator) AddSidecarNamespaceCosts(namespaceCosts []NamespaceCost, sidecarCosts []SidecarCost) []NamespaceCost {
+	for _, sidecar := range sidecarCosts {
+		namespaceCosts = chargeNamespace(namespaceCosts, sidecar.ChargedNamespace, sidecar.HourlyCost)
+	}
+	return namespaceCosts
+}
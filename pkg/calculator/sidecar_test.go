@@ -0,0 +1,64 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/config"
+)
+
+func TestCarveOutSidecarCosts(t *testing.T) {
+	podCosts := []PodCost{{
+		PodName:    "web",
+		Namespace:  "shop",
+		HourlyCost: 10,
+		CPUCost:    6,
+		MemoryCost: 4,
+		Containers: []ContainerCost{
+			{Name: "app", HourlyCost: 5},
+			{Name: "istio-proxy", HourlyCost: 2},
+			{Name: "vault-agent", HourlyCost: 3},
+		},
+	}}
+	rules := []config.SidecarRule{
+		{Owner: "mesh-team", Containers: []string{"istio-proxy"}, Namespace: "istio-system"},
+		{Owner: "security", Containers: []string{"vault-agent"}},
+	}
+
+	cc := NewCostCalculator(Options{})
+	podCosts, sidecars := cc.CarveOutSidecarCosts(podCosts, rules)
+
+	pod := podCosts[0]
+	if !approxEqual(pod.HourlyCost, 5) || !approxEqual(pod.CPUCost+pod.MemoryCost, 5) {
+		t.Errorf("pod cost = %v (compute %v), want 5", pod.HourlyCost, pod.CPUCost+pod.MemoryCost)
+	}
+	wantContainers := map[string]float64{"app": 5, "istio-proxy": 0, "vault-agent": 0}
+	for _, container := range pod.Containers {
+		if !approxEqual(container.HourlyCost, wantContainers[container.Name]) {
+			t.Errorf("container %s cost = %v, want %v", container.Name, container.HourlyCost, wantContainers[container.Name])
+		}
+	}
+
+	wantSidecars := []SidecarCost{
+		{Container: "istio-proxy", Owner: "mesh-team", Namespace: "shop", ChargedNamespace: "istio-system", HourlyCost: 2, PodCount: 1},
+		{Container: "vault-agent", Owner: "security", Namespace: "shop", ChargedNamespace: SidecarNamespace, HourlyCost: 3, PodCount: 1},
+	}
+	if len(sidecars) != len(wantSidecars) {
+		t.Fatalf("got %d sidecars, want %d", len(sidecars), len(wantSidecars))
+	}
+	for i, want := range wantSidecars {
+		got := sidecars[i]
+		if got.Container != want.Container || got.Owner != want.Owner || got.Namespace != want.Namespace ||
+			got.ChargedNamespace != want.ChargedNamespace || got.PodCount != want.PodCount || !approxEqual(got.HourlyCost, want.HourlyCost) {
+			t.Errorf("sidecar %d = %+v, want %+v", i, got, want)
+		}
+	}
+
+	namespaceCosts := cc.AddSidecarNamespaceCosts(cc.CalculateNamespaceCosts(podCosts), sidecars)
+	var total float64
+	for _, ns := range namespaceCosts {
+		total += ns.HourlyCost
+	}
+	if !approxEqual(total, 10) {
+		t.Errorf("namespace costs = %v, want the pod's original cost 10", total)
+	}
+}
@@ -1,6 +1,8 @@
 package calculator
 
 import (
+	"sort"
+
 	"github.com/deepcost/kube-cost-exporter/pkg/collector"
 )
 
@@ -14,6 +16,10 @@ type StorageCost struct {
 	MonthlyCost  float64
 	DailyCost    float64
 	HourlyCost   float64
+
+	// Workload the claim belongs to when no pod mounts it
+	OwnerKind string
+	OwnerName string
 }
 
 // NamespaceStorageCost represents aggregated storage cost for a namespace
@@ -40,7 +46,111 @@ func (cc *CostCalculator) CalculateStorageCost(pvInfo collector.PVInfo) StorageC
 		MonthlyCost:  monthlyCost,
 		DailyCost:    dailyCost,
 		HourlyCost:   hourlyCost,
+		OwnerKind:    pvInfo.OwnerKind,
+		OwnerName:    pvInfo.OwnerName,
+	}
+}
+
+// StorageAllocation is the part of a volume's cost attributed to a pod, or to a
+// workload when no pod mounts the volume
+type StorageAllocation struct {
+	PVName     string
+	Namespace  string
+	PVCName    string
+	PodName    string // empty for volumes attributed to a workload
+	OwnerKind  string
+	OwnerName  string
+	HourlyCost float64
+}
+
+// AllocateStorageCosts attributes volume costs to the pods that mount their claims,
+// split evenly between pods that share a volume, and adds them to the pods' storage
+// cost. Volumes no pod mounts are attributed to the workload their claim belongs
+// to, if known.
+func (cc *CostCalculator) AllocateStorageCosts(podCosts []PodCost, storageCosts []StorageCost) ([]PodCost, []StorageAllocation) {
+	claimPods := make(map[string][]int) // pod indices by namespace/claim
+	for i, podCost := range podCosts {
+		for _, claim := range podCost.PVCs {
+			key := podCost.Namespace + "/" + claim
+			claimPods[key] = append(claimPods[key], i)
+		}
+	}
+
+	var allocations []StorageAllocation
+	for _, cost := range storageCosts {
+		if cost.PVCName == "" {
+			continue // Skip unbound PVs
+		}
+
+		pods := claimPods[cost.Namespace+"/"+cost.PVCName]
+		if len(pods) == 0 {
+			if cost.OwnerKind == "" {
+				continue
+			}
+			allocations = append(allocations, StorageAllocation{
+				PVName:     cost.PVName,
+				Namespace:  cost.Namespace,
+				PVCName:    cost.PVCName,
+				OwnerKind:  cost.OwnerKind,
+				OwnerName:  cost.OwnerName,
+				HourlyCost: cost.HourlyCost,
+			})
+			continue
+		}
+
+		share := cost.HourlyCost / float64(len(pods))
+		for _, i := range pods {
+			podCosts[i].StorageCost += share
+			allocations = append(allocations, StorageAllocation{
+				PVName:     cost.PVName,
+				Namespace:  cost.Namespace,
+				PVCName:    cost.PVCName,
+				PodName:    podCosts[i].PodName,
+				OwnerKind:  podCosts[i].OwnerKind,
+				OwnerName:  podCosts[i].OwnerName,
+				HourlyCost: share,
+			})
+		}
+	}
+
+	return podCosts, allocations
+}
+
+// AddWorkloadStorageCosts adds storage allocations to the storage cost of their
+// workloads. Workloads with storage but no running pods are added.
+func (cc *CostCalculator) AddWorkloadStorageCosts(workloadCosts []WorkloadCost, allocations []StorageAllocation) []WorkloadCost {
+	type workloadKey struct{ namespace, kind, name string }
+	index := make(map[workloadKey]int, len(workloadCosts))
+	for i, workload := range workloadCosts {
+		index[workloadKey{workload.Namespace, workload.Kind, workload.Name}] = i
+	}
+
+	for _, allocation := range allocations {
+		key := workloadKey{allocation.Namespace, allocation.OwnerKind, allocation.OwnerName}
+		i, ok := index[key]
+		if !ok {
+			i = len(workloadCosts)
+			index[key] = i
+			workloadCosts = append(workloadCosts, WorkloadCost{
+				Namespace: allocation.Namespace,
+				Kind:      allocation.OwnerKind,
+				Name:      allocation.OwnerName,
+			})
+		}
+		workloadCosts[i].StorageCost += allocation.HourlyCost
 	}
+
+	sort.SliceStable(workloadCosts, func(i, j int) bool {
+		a, b := workloadCosts[i], workloadCosts[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return workloadCosts
 }
 
 // CalculateNamespaceStorageCosts aggregates storage costs by namespace
@@ -0,0 +1,165 @@
+package calculator
+
+import (
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+)
+
+// Allocation strategies select the formula that splits node costs between pods
+const (
+	StrategyUnitRate         = "unit-rate"         // resources at the node's unit rates
+	StrategyDominantResource = "dominant-resource" // the larger of the pod's CPU and memory share of the node
+	StrategyWeighted         = "weighted"          // weighted sum of the pod's CPU and memory share of the node
+	StrategyUsage            = "usage"             // resource usage at the node's unit rates
+	StrategyEvenSplit        = "even-split"        // an equal share of the node per pod
+)
+
+// AllocationStrategy splits a node's cost between the pods on it
+type AllocationStrategy interface {
+	// Allocate returns the pod's hourly CPU, memory and GPU costs
+	Allocate(in AllocationInput) ResourceCosts
+}
+
+// AllocationInput is what an allocation strategy allocates a pod's cost from
+type AllocationInput struct {
+	Pod        collector.PodInfo
+	Node       collector.NodeInfo
+	Rates      UnitRates
+	PodsOnNode int
+
+	// Resources allocated to the pod per the allocation mode, and the node capacity
+	// they are allocated from per the overhead policy
+	CPU            int64 // millicores
+	Memory         int64 // bytes
+	CPUCapacity    int64 // millicores
+	MemoryCapacity int64 // bytes
+
+	// Node capacity charged to the system namespace instead of pods
+	CPUReserved    int64 // millicores
+	MemoryReserved int64 // bytes
+}
+
+// ResourceCosts are a pod's hourly costs per resource
+type ResourceCosts struct {
+	CPU    float64
+	Memory float64
+	GPU    float64
+}
+
+// NewAllocationStrategy returns the built-in strategy with the given name.
+// cpuWeight and memoryWeight are only used by the weighted strategy.
+func NewAllocationStrategy(name string, cpuWeight, memoryWeight float64) (AllocationStrategy, bool) {
+	switch name {
+	case StrategyUnitRate:
+		return unitRateStrategy{}, true
+	case StrategyDominantResource:
+		return dominantResourceStrategy{}, true
+	case StrategyWeighted:
+		if cpuWeight+memoryWeight <= 0 {
+			cpuWeight, memoryWeight = 0.5, 0.5
+		}
+		return weightedStrategy{
+			cpuWeight:    cpuWeight / (cpuWeight + memoryWeight),
+			memoryWeight: memoryWeight / (cpuWeight + memoryWeight),
+		}, true
+	case StrategyUsage:
+		return usageStrategy{}, true
+	case StrategyEvenSplit:
+		return evenSplitStrategy{}, true
+	}
+	return nil, false
+}
+
+// ValidStrategy reports whether name is a built-in allocation strategy
+func ValidStrategy(name string) bool {
+	_, ok := NewAllocationStrategy(name, 0, 0)
+	return ok
+}
+
+// unitRateStrategy charges each resource at the node's unit rates, so the costs of
+// pods that allocate the whole node add up to its price
+type unitRateStrategy struct{}
+
+func (unitRateStrategy) Allocate(in AllocationInput) ResourceCosts {
+	return rateCosts(in.CPU, in.Memory, in.Pod.GPURequest, in.Rates)
+}
+
+// dominantResourceStrategy charges the larger of the pod's CPU and memory share of
+// the node's CPU and memory cost, split between CPU and memory by their unit rate costs
+type dominantResourceStrategy struct{}
+
+func (dominantResourceStrategy) Allocate(in AllocationInput) ResourceCosts {
+	fraction := max(float64(in.CPU)/float64(in.CPUCapacity), float64(in.Memory)/float64(in.MemoryCapacity))
+	costs := rateCosts(in.CPU, in.Memory, in.Pod.GPURequest, in.Rates)
+	return scaleComputeCost(costs, fraction*nonGPUPrice(in))
+}
+
+// weightedStrategy charges a weighted sum of the pod's CPU and memory share of the
+// node's CPU and memory cost
+type weightedStrategy struct {
+	cpuWeight    float64
+	memoryWeight float64
+}
+
+func (s weightedStrategy) Allocate(in AllocationInput) ResourceCosts {
+	price := nonGPUPrice(in)
+	return ResourceCosts{
+		CPU:    price * s.cpuWeight * float64(in.CPU) / float64(in.CPUCapacity),
+		Memory: price * s.memoryWeight * float64(in.Memory) / float64(in.MemoryCapacity),
+		GPU:    float64(in.Pod.GPURequest) * in.Rates.GPUHourly,
+	}
+}
+
+// usageStrategy charges the pod's usage at the node's unit rates regardless of the
+// allocation mode. Pods without usage samples are charged by their allocation.
+type usageStrategy struct{}
+
+func (usageStrategy) Allocate(in AllocationInput) ResourceCosts {
+	if !in.Pod.HasUsage {
+		return rateCosts(in.CPU, in.Memory, in.Pod.GPURequest, in.Rates)
+	}
+	return rateCosts(in.Pod.CPUUsage, in.Pod.MemoryUsage, in.Pod.GPURequest, in.Rates)
+}
+
+// evenSplitStrategy charges each pod on the node an equal share of its price, less
+// any capacity charged to the system namespace, split between resources by their
+// unit rate costs over the node's capacity. It suits best-effort pods, which have
+// no requests to allocate by.
+type evenSplitStrategy struct{}
+
+func (evenSplitStrategy) Allocate(in AllocationInput) ResourceCosts {
+	share := 1 / float64(max(in.PodsOnNode, 1))
+	costs := rateCosts(in.CPUCapacity-in.CPUReserved, in.MemoryCapacity-in.MemoryReserved, in.Node.GPUCapacity, in.Rates)
+	return ResourceCosts{
+		CPU:    costs.CPU * share,
+		Memory: costs.Memory * share,
+		GPU:    costs.GPU * share,
+	}
+}
+
+// rateCosts prices CPU (millicores), memory (bytes) and GPUs at the unit rates
+func rateCosts(cpu, memory, gpus int64, rates UnitRates) ResourceCosts {
+	return ResourceCosts{
+		CPU:    float64(cpu) / 1000 * rates.CPUCoreHourly,
+		Memory: float64(memory) / (1024 * 1024 * 1024) * rates.MemoryGiBHourly,
+		GPU:    float64(gpus) * rates.GPUHourly,
+	}
+}
+
+// scaleComputeCost rescales the CPU and memory costs to add up to total, keeping
+// their proportions
+func scaleComputeCost(costs ResourceCosts, total float64) ResourceCosts {
+	sum := costs.CPU + costs.Memory
+	if sum <= 0 {
+		return ResourceCosts{GPU: costs.GPU}
+	}
+	return ResourceCosts{
+		CPU:    costs.CPU * total / sum,
+		Memory: costs.Memory * total / sum,
+		GPU:    costs.GPU,
+	}
+}
+
+// nonGPUPrice returns the part of the node price for its CPU and memory
+func nonGPUPrice(in AllocationInput) float64 {
+	return max(in.Node.HourlyPrice-float64(in.Node.GPUCapacity)*in.Rates.GPUHourly, 0)
+}
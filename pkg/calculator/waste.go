@@ -0,0 +1,118 @@
+package calculator
+
+import (
+	"sort"
+	"time"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Reasons a volume is wasted
+const (
+	WasteUnbound   = "unbound"   // the volume is not bound to a claim
+	WasteReleased  = "released"  // the claim was deleted and the volume is waiting to be reclaimed
+	WasteRetained  = "retained"  // the claim was deleted and the volume is kept by its Retain policy
+	WasteUnmounted = "unmounted" // the claim is bound but no pod has mounted it for longer than the threshold
+)
+
+// WastedVolume is a persistent volume that is paid for but not used
+type WastedVolume struct {
+	PVName       string
+	Namespace    string // empty for unbound volumes
+	PVCName      string
+	StorageClass string
+	Reason       string
+	SizeGB       int64
+	MonthlyCost  float64
+	Age          time.Duration // how long the volume has been wasted
+}
+
+// WasteDetector finds persistent volumes that are paid for but not used. It
+// remembers when each claim was last mounted by a pod, so claims are only
+// reported as unmounted once they have been unused for longer than the threshold.
+// Claims are assumed mounted when the detector starts, as mounts are only tracked
+// in memory, so an agent restart resets how long claims have been unmounted.
+type WasteDetector struct {
+	unmountedThreshold time.Duration
+	started            time.Time
+	lastMounted        map[string]time.Time // namespace/claim
+}
+
+// NewWasteDetector creates a new waste detector
+func NewWasteDetector(unmountedThreshold time.Duration) *WasteDetector {
+	return &WasteDetector{
+		unmountedThreshold: unmountedThreshold,
+		lastMounted:        make(map[string]time.Time),
+	}
+}
+
+// Detect returns the volumes that are wasted at now. Claims mounted by any
+// running or pending pod are in use.
+func (wd *WasteDetector) Detect(now time.Time, pvs []collector.PVInfo, pods []collector.PodInfo) []WastedVolume {
+	if wd.started.IsZero() {
+		wd.started = now
+	}
+
+	for _, pod := range pods {
+		for _, claim := range pod.PVCs {
+			wd.lastMounted[pod.Namespace+"/"+claim] = now
+		}
+	}
+
+	var wasted []WastedVolume
+	bound := make(map[string]bool)
+	for _, pv := range pvs {
+		volume := WastedVolume{
+			PVName:       pv.Name,
+			Namespace:    pv.Namespace,
+			PVCName:      pv.PVCName,
+			StorageClass: pv.StorageClass,
+			SizeGB:       pv.SizeGB,
+			MonthlyCost:  pv.MonthlyCost,
+			Age:          now.Sub(pv.PhaseTime),
+		}
+
+		switch pv.Phase {
+		case corev1.VolumeBound:
+			key := pv.Namespace + "/" + pv.PVCName
+			bound[key] = true
+
+			lastMounted, ok := wd.lastMounted[key]
+			if !ok {
+				lastMounted = wd.started
+				if pv.PhaseTime.After(lastMounted) {
+					lastMounted = pv.PhaseTime
+				}
+				wd.lastMounted[key] = lastMounted
+			}
+			if now.Sub(lastMounted) <= wd.unmountedThreshold {
+				continue
+			}
+			volume.Reason = WasteUnmounted
+			volume.Age = now.Sub(lastMounted)
+		case corev1.VolumeReleased:
+			volume.Reason = WasteReleased
+			if pv.ReclaimPolicy == corev1.PersistentVolumeReclaimRetain {
+				volume.Reason = WasteRetained
+			}
+		case corev1.VolumeAvailable, corev1.VolumePending, corev1.VolumeFailed:
+			volume.Reason = WasteUnbound
+			volume.Namespace, volume.PVCName = "", ""
+		default:
+			continue
+		}
+
+		wasted = append(wasted, volume)
+	}
+
+	// Forget claims whose volumes are gone or no longer bound
+	for key := range wd.lastMounted {
+		if !bound[key] {
+			delete(wd.lastMounted, key)
+		}
+	}
+
+	sort.Slice(wasted, func(i, j int) bool { return wasted[i].PVName < wasted[j].PVName })
+	return wasted
+}
@@ -0,0 +1,59 @@
+package calculator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/collector"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestWasteDetector(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	created := start.Add(-30 * 24 * time.Hour)
+	pvs := []collector.PVInfo{
+		{Name: "pv-free", Phase: corev1.VolumeAvailable, PhaseTime: created, Namespace: "shop", PVCName: "old"},
+		{Name: "pv-released", Phase: corev1.VolumeReleased, ReclaimPolicy: corev1.PersistentVolumeReclaimDelete, PhaseTime: created},
+		{Name: "pv-retained", Phase: corev1.VolumeReleased, ReclaimPolicy: corev1.PersistentVolumeReclaimRetain, PhaseTime: created},
+		{Name: "pv-mounted", Phase: corev1.VolumeBound, PhaseTime: created, Namespace: "shop", PVCName: "data"},
+		{Name: "pv-unmounted", Phase: corev1.VolumeBound, PhaseTime: created, Namespace: "shop", PVCName: "cache"},
+	}
+	pods := []collector.PodInfo{{Name: "db", Namespace: "shop", PVCs: []string{"data"}}}
+
+	wd := NewWasteDetector(24 * time.Hour)
+
+	// Claims are assumed mounted when the detector starts
+	wasted := wd.Detect(start, pvs, pods)
+	want := map[string]string{"pv-free": WasteUnbound, "pv-released": WasteReleased, "pv-retained": WasteRetained}
+	if len(wasted) != len(want) {
+		t.Fatalf("got %d wasted volumes at start, want %d: %+v", len(wasted), len(want), wasted)
+	}
+	for _, volume := range wasted {
+		if volume.Reason != want[volume.PVName] {
+			t.Errorf("%s reason = %q, want %q", volume.PVName, volume.Reason, want[volume.PVName])
+		}
+	}
+	if wasted[0].PVName != "pv-free" || wasted[0].Namespace != "" || wasted[0].PVCName != "" {
+		t.Errorf("unbound volume = %+v, want no claim", wasted[0])
+	}
+
+	// The unmounted claim is reported once it has been unused past the threshold,
+	// aged from when the detector started
+	now := start.Add(36 * time.Hour)
+	wasted = wd.Detect(now, pvs, pods)
+	var unmounted *WastedVolume
+	for i := range wasted {
+		if wasted[i].PVName == "pv-mounted" {
+			t.Errorf("mounted volume reported as %q", wasted[i].Reason)
+		}
+		if wasted[i].PVName == "pv-unmounted" {
+			unmounted = &wasted[i]
+		}
+	}
+	if unmounted == nil {
+		t.Fatalf("unmounted volume not reported: %+v", wasted)
+	}
+	if unmounted.Reason != WasteUnmounted || unmounted.Age != 36*time.Hour {
+		t.Errorf("unmounted volume = %+v, want reason %q and age 36h", unmounted, WasteUnmounted)
+	}
+}
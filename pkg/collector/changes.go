@@ -0,0 +1,82 @@
+package collector
+
+import (
+	"sync"
+)
+
+// Object kinds tracked by ChangeTracker
+const (
+	KindNode                  = "Node"
+	KindPod                   = "Pod"
+	KindPersistentVolume      = "PersistentVolume"
+	KindPersistentVolumeClaim = "PersistentVolumeClaim"
+	KindStorageClass          = "StorageClass"
+)
+
+// ChangeTracker records which objects have changed since a collector last
+// processed them, so collectors only recompute the objects that changed
+type ChangeTracker struct {
+	mu    sync.Mutex
+	dirty map[string]map[string]bool // kind -> object key -> deleted
+	full  map[string]bool            // kinds that need a full recalculation
+}
+
+// NewChangeTracker creates a change tracker. Every kind starts out needing a
+// full recalculation.
+func NewChangeTracker() *ChangeTracker {
+	return &ChangeTracker{
+		dirty: make(map[string]map[string]bool),
+		full: map[string]bool{
+			KindNode:                  true,
+			KindPod:                   true,
+			KindPersistentVolume:      true,
+			KindPersistentVolumeClaim: true,
+			KindStorageClass:          true,
+		},
+	}
+}
+
+// MarkChanged records that an object was added or updated
+func (ct *ChangeTracker) MarkChanged(kind, key string) {
+	ct.mark(kind, key, false)
+}
+
+// MarkDeleted records that an object was deleted
+func (ct *ChangeTracker) MarkDeleted(kind, key string) {
+	ct.mark(kind, key, true)
+}
+
+// MarkAll records that every object of a kind needs to be recalculated
+func (ct *ChangeTracker) MarkAll(kind string) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	ct.full[kind] = true
+	delete(ct.dirty, kind)
+}
+
+// TakeChanges returns the keys of the objects of a kind that changed since the
+// last call, mapped to whether they were deleted, and clears them. full is true
+// when every object must be recalculated.
+func (ct *ChangeTracker) TakeChanges(kind string) (changes map[string]bool, full bool) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	changes, full = ct.dirty[kind], ct.full[kind]
+	delete(ct.dirty, kind)
+	delete(ct.full, kind)
+	return changes, full
+}
+
+func (ct *ChangeTracker) mark(kind, key string, deleted bool) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if ct.full[kind] {
+		return
+	}
+	if ct.dirty[kind] == nil {
+		ct.dirty[kind] = make(map[string]bool)
+	}
+	ct.dirty[kind][key] = deleted
+}
@@ -0,0 +1,207 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// podNodeNameIndex indexes pods by spec.nodeName
+const podNodeNameIndex = "nodeName"
+
+// InformerCache keeps a local cache of the cluster objects the collectors read,
+// using shared informers so each collection cycle reads from listers instead of
+// listing from the API server
+type InformerCache struct {
+	factory informers.SharedInformerFactory
+	changes *ChangeTracker
+	logger  *logrus.Logger
+
+	podInformer cache.SharedIndexInformer
+	podIndexer  cache.Indexer
+
+	Nodes                  corelisters.NodeLister
+	Pods                   corelisters.PodLister
+	PersistentVolumes      corelisters.PersistentVolumeLister
+	PersistentVolumeClaims corelisters.PersistentVolumeClaimLister
+	StorageClasses         storagelisters.StorageClassLister
+	ReplicaSets            appslisters.ReplicaSetLister
+	StatefulSets           appslisters.StatefulSetLister
+	Jobs                   batchlisters.JobLister
+	Namespaces             corelisters.NamespaceLister
+}
+
+// NewInformerCache creates shared informers for nodes, pods, persistent volumes,
+// persistent volume claims and storage classes. Every resync period all objects
+// are marked changed, so collectors periodically recalculate everything.
+// ReplicaSets and Jobs are also cached for resolving pod owners, StatefulSets for
+// resolving volume claim owners, and namespaces for aggregating costs by their
+// labels and annotations.
+func NewInformerCache(clientset kubernetes.Interface, resync time.Duration) (*InformerCache, error) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+
+	// Managed fields are large and never read, so drop them to save memory
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, resync,
+		informers.WithTransform(func(obj interface{}) (interface{}, error) {
+			if accessor, ok := obj.(metav1.ObjectMetaAccessor); ok {
+				accessor.GetObjectMeta().SetManagedFields(nil)
+			}
+			return obj, nil
+		}),
+	)
+
+	ic := &InformerCache{
+		factory: factory,
+		changes: NewChangeTracker(),
+		logger:  logger,
+	}
+
+	nodeInformer := factory.Core().V1().Nodes()
+	podInformer := factory.Core().V1().Pods()
+	pvInformer := factory.Core().V1().PersistentVolumes()
+	pvcInformer := factory.Core().V1().PersistentVolumeClaims()
+	storageClassInformer := factory.Storage().V1().StorageClasses()
+
+	if err := podInformer.Informer().AddIndexers(cache.Indexers{podNodeNameIndex: indexPodByNodeName}); err != nil {
+		return nil, fmt.Errorf("failed to add pod node name index: %w", err)
+	}
+	ic.podInformer = podInformer.Informer()
+	ic.podIndexer = podInformer.Informer().GetIndexer()
+
+	handlers := map[string]cache.SharedIndexInformer{
+		KindNode:                  nodeInformer.Informer(),
+		KindPod:                   podInformer.Informer(),
+		KindPersistentVolume:      pvInformer.Informer(),
+		KindPersistentVolumeClaim: pvcInformer.Informer(),
+		KindStorageClass:          storageClassInformer.Informer(),
+	}
+	for kind, informer := range handlers {
+		if _, err := informer.AddEventHandler(ic.changeHandler(kind)); err != nil {
+			return nil, fmt.Errorf("failed to add %s event handler: %w", kind, err)
+		}
+	}
+
+	ic.Nodes = nodeInformer.Lister()
+	ic.Pods = podInformer.Lister()
+	ic.PersistentVolumes = pvInformer.Lister()
+	ic.PersistentVolumeClaims = pvcInformer.Lister()
+	ic.StorageClasses = storageClassInformer.Lister()
+	ic.ReplicaSets = factory.Apps().V1().ReplicaSets().Lister()
+	ic.StatefulSets = factory.Apps().V1().StatefulSets().Lister()
+	ic.Jobs = factory.Batch().V1().Jobs().Lister()
+	ic.Namespaces = factory.Core().V1().Namespaces().Lister()
+
+	return ic, nil
+}
+
+// Start starts the informers and waits for their caches to sync
+func (ic *InformerCache) Start(ctx context.Context) error {
+	ic.factory.Start(ctx.Done())
+
+	for informerType, synced := range ic.factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return fmt.Errorf("failed to sync informer cache for %v", informerType)
+		}
+	}
+
+	ic.logger.Info("Informer caches synced")
+	return nil
+}
+
+// Changes returns the tracker of objects changed since collectors last read them
+func (ic *InformerCache) Changes() *ChangeTracker {
+	return ic.changes
+}
+
+// AddPodEventHandler registers an additional handler for pod events. It must be
+// called before Start.
+func (ic *InformerCache) AddPodEventHandler(handler cache.ResourceEventHandler) error {
+	_, err := ic.podInformer.AddEventHandler(handler)
+	return err
+}
+
+// PodsOnNode returns the pods scheduled to a node
+func (ic *InformerCache) PodsOnNode(nodeName string) ([]*corev1.Pod, error) {
+	objs, err := ic.podIndexer.ByIndex(podNodeNameIndex, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]*corev1.Pod, 0, len(objs))
+	for _, obj := range objs {
+		if pod, ok := obj.(*corev1.Pod); ok {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+// changeHandler returns an event handler that marks objects of a kind as changed
+func (ic *InformerCache) changeHandler(kind string) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			ic.markChanged(kind, obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if kind == KindNode && !nodeChanged(oldObj, newObj) {
+				return
+			}
+			ic.markChanged(kind, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+			if err != nil {
+				ic.logger.Warnf("Failed to get key for deleted %s: %v", kind, err)
+				return
+			}
+			ic.changes.MarkDeleted(kind, key)
+		},
+	}
+}
+
+func (ic *InformerCache) markChanged(kind string, obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		ic.logger.Warnf("Failed to get key for %s: %v", kind, err)
+		return
+	}
+	ic.changes.MarkChanged(kind, key)
+}
+
+// nodeChanged reports whether a node update affects its pricing. Status
+// heartbeats are ignored; resyncs (same resource version) always count.
+func nodeChanged(oldObj, newObj interface{}) bool {
+	oldNode, ok1 := oldObj.(*corev1.Node)
+	newNode, ok2 := newObj.(*corev1.Node)
+	if !ok1 || !ok2 {
+		return true
+	}
+
+	return oldNode.ResourceVersion == newNode.ResourceVersion ||
+		!reflect.DeepEqual(oldNode.Labels, newNode.Labels) ||
+		oldNode.Spec.ProviderID != newNode.Spec.ProviderID ||
+		!reflect.DeepEqual(oldNode.Status.Capacity, newNode.Status.Capacity) ||
+		!reflect.DeepEqual(oldNode.Status.Allocatable, newNode.Status.Allocatable)
+}
+
+// indexPodByNodeName indexes pods by the node they are scheduled to
+func indexPodByNodeName(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || pod.Spec.NodeName == "" {
+		return nil, nil
+	}
+	return []string{pod.Spec.NodeName}, nil
+}
@@ -0,0 +1,289 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Kubelet summary requests are spread over a fixed number of workers, each with
+// its own timeout, so a few slow kubelets do not stall a collection cycle
+const (
+	kubeletWorkers        = 16
+	kubeletRequestTimeout = 10 * time.Second
+)
+
+// KubeletCollector collects per-container usage, ephemeral storage and network
+// traffic from each node's kubelet /stats/summary endpoint through the API server
+// node proxy
+type KubeletCollector struct {
+	clientset kubernetes.Interface
+	informers *InformerCache
+	usage     *UsageCollector
+	logger    *logrus.Logger
+
+	mu      sync.Mutex
+	stats   map[string]PodStats       // namespace/name -> latest stats
+	network map[string]networkCounter // namespace/name -> last cumulative network counters
+}
+
+// PodStats contains the latest kubelet statistics for a pod
+type PodStats struct {
+	Containers            []ContainerUsage
+	EphemeralStorageUsage int64   // bytes
+	NetworkTxBytesPerHour float64 // egress rate since the previous sample
+	NetworkRxBytesPerHour float64
+}
+
+// ContainerUsage contains the usage of a single container
+type ContainerUsage struct {
+	Name                  string
+	CPUUsage              int64 // millicores
+	MemoryUsage           int64 // working set bytes
+	EphemeralStorageUsage int64 // writable layer and log bytes
+}
+
+// networkCounter is a pod's cumulative network byte counters at a point in time
+type networkCounter struct {
+	timestamp time.Time
+	txBytes   uint64
+	rxBytes   uint64
+}
+
+// kubeletSummary is the kubelet /stats/summary format
+type kubeletSummary struct {
+	Pods []kubeletPodStats `json:"pods"`
+}
+
+type kubeletPodStats struct {
+	PodRef struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"podRef"`
+	Containers       []kubeletContainerStats `json:"containers"`
+	Network          *kubeletNetworkStats    `json:"network"`
+	EphemeralStorage *kubeletFsStats         `json:"ephemeral-storage"`
+}
+
+type kubeletContainerStats struct {
+	Name   string              `json:"name"`
+	CPU    *kubeletCPUStats    `json:"cpu"`
+	Memory *kubeletMemoryStats `json:"memory"`
+	Rootfs *kubeletFsStats     `json:"rootfs"`
+	Logs   *kubeletFsStats     `json:"logs"`
+}
+
+type kubeletCPUStats struct {
+	Time           time.Time `json:"time"`
+	UsageNanoCores *uint64   `json:"usageNanoCores"`
+}
+
+type kubeletMemoryStats struct {
+	WorkingSetBytes *uint64 `json:"workingSetBytes"`
+}
+
+type kubeletFsStats struct {
+	UsedBytes *uint64 `json:"usedBytes"`
+}
+
+type kubeletNetworkStats struct {
+	Time    time.Time `json:"time"`
+	RxBytes *uint64   `json:"rxBytes"`
+	TxBytes *uint64   `json:"txBytes"`
+}
+
+// NewKubeletCollector creates a new kubelet summary collector. Pod CPU and memory
+// usage is recorded in the usage collector's window.
+func NewKubeletCollector(clientset kubernetes.Interface, informers *InformerCache, usage *UsageCollector) *KubeletCollector {
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+
+	return &KubeletCollector{
+		clientset: clientset,
+		informers: informers,
+		usage:     usage,
+		logger:    logger,
+		stats:     make(map[string]PodStats),
+		network:   make(map[string]networkCounter),
+	}
+}
+
+// CollectStats queries the kubelet summary of every node
+func (kc *KubeletCollector) CollectStats(ctx context.Context) error {
+	nodes, err := kc.informers.Nodes.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	nodeNames := make(chan string)
+	summaries := make(chan kubeletSummary)
+
+	var wg sync.WaitGroup
+	for i := 0; i < kubeletWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for nodeName := range nodeNames {
+				summary, err := kc.getSummary(ctx, nodeName)
+				if err != nil {
+					kc.logger.Warnf("Failed to get kubelet stats for node %s: %v", nodeName, err)
+					continue
+				}
+				summaries <- summary
+			}
+		}()
+	}
+
+	go func() {
+		for _, node := range nodes {
+			nodeNames <- node.Name
+		}
+		close(nodeNames)
+		wg.Wait()
+		close(summaries)
+	}()
+
+	stats := make(map[string]PodStats)
+	for summary := range summaries {
+		for _, pod := range summary.Pods {
+			key := pod.PodRef.Namespace + "/" + pod.PodRef.Name
+			stats[key] = kc.podStats(&pod)
+		}
+	}
+
+	kc.mu.Lock()
+	kc.stats = stats
+	// Forget network counters of pods that are gone
+	for key := range kc.network {
+		if _, ok := stats[key]; !ok {
+			delete(kc.network, key)
+		}
+	}
+	kc.mu.Unlock()
+
+	kc.logger.Debugf("Collected kubelet stats for %d pods on %d nodes", len(stats), len(nodes))
+	return nil
+}
+
+// ApplyStats sets container usage, ephemeral storage and network traffic on each pod
+func (kc *KubeletCollector) ApplyStats(pods []PodInfo) {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+
+	for i := range pods {
+		stats, ok := kc.stats[pods[i].Namespace+"/"+pods[i].Name]
+		if !ok {
+			continue
+		}
+		pods[i].Containers = stats.Containers
+		pods[i].EphemeralStorageUsage = stats.EphemeralStorageUsage
+		pods[i].HasEphemeralStorageUsage = true
+		pods[i].NetworkTxBytesPerHour = stats.NetworkTxBytesPerHour
+		pods[i].NetworkRxBytesPerHour = stats.NetworkRxBytesPerHour
+	}
+}
+
+// getSummary reads a node's kubelet summary through the API server proxy
+func (kc *KubeletCollector) getSummary(ctx context.Context, nodeName string) (kubeletSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, kubeletRequestTimeout)
+	defer cancel()
+
+	data, err := kc.clientset.CoreV1().RESTClient().Get().
+		Resource("nodes").
+		Name(nodeName).
+		SubResource("proxy").
+		Suffix("stats/summary").
+		DoRaw(ctx)
+	if err != nil {
+		return kubeletSummary{}, err
+	}
+
+	var summary kubeletSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return kubeletSummary{}, fmt.Errorf("failed to parse kubelet summary: %w", err)
+	}
+	return summary, nil
+}
+
+// podStats converts a pod's kubelet statistics, recording its CPU and memory usage
+// and updating its network rate
+func (kc *KubeletCollector) podStats(pod *kubeletPodStats) PodStats {
+	var stats PodStats
+	var cpuUsage, memoryUsage int64
+	var timestamp time.Time
+
+	for _, container := range pod.Containers {
+		usage := ContainerUsage{Name: container.Name}
+		if container.CPU != nil && container.CPU.UsageNanoCores != nil {
+			usage.CPUUsage = int64(*container.CPU.UsageNanoCores / 1000000)
+			if container.CPU.Time.After(timestamp) {
+				timestamp = container.CPU.Time
+			}
+		}
+		if container.Memory != nil && container.Memory.WorkingSetBytes != nil {
+			usage.MemoryUsage = int64(*container.Memory.WorkingSetBytes)
+		}
+		if container.Rootfs != nil && container.Rootfs.UsedBytes != nil {
+			usage.EphemeralStorageUsage += int64(*container.Rootfs.UsedBytes)
+		}
+		if container.Logs != nil && container.Logs.UsedBytes != nil {
+			usage.EphemeralStorageUsage += int64(*container.Logs.UsedBytes)
+		}
+
+		cpuUsage += usage.CPUUsage
+		memoryUsage += usage.MemoryUsage
+		stats.Containers = append(stats.Containers, usage)
+	}
+
+	if !timestamp.IsZero() {
+		kc.usage.RecordUsage(pod.PodRef.Namespace, pod.PodRef.Name, timestamp, cpuUsage, memoryUsage)
+	}
+
+	// Pod-level ephemeral storage also includes emptyDir volumes
+	if pod.EphemeralStorage != nil && pod.EphemeralStorage.UsedBytes != nil {
+		stats.EphemeralStorageUsage = int64(*pod.EphemeralStorage.UsedBytes)
+	}
+
+	if pod.Network != nil && pod.Network.TxBytes != nil && pod.Network.RxBytes != nil && !kc.isHostNetwork(pod) {
+		stats.NetworkTxBytesPerHour, stats.NetworkRxBytesPerHour = kc.networkRate(pod)
+	}
+
+	return stats
+}
+
+// networkRate returns a pod's transmit and receive rates in bytes per hour since its
+// previous sample, and stores the current counters
+func (kc *KubeletCollector) networkRate(pod *kubeletPodStats) (float64, float64) {
+	key := pod.PodRef.Namespace + "/" + pod.PodRef.Name
+	current := networkCounter{
+		timestamp: pod.Network.Time,
+		txBytes:   *pod.Network.TxBytes,
+		rxBytes:   *pod.Network.RxBytes,
+	}
+
+	kc.mu.Lock()
+	previous, ok := kc.network[key]
+	kc.network[key] = current
+	kc.mu.Unlock()
+
+	hours := current.timestamp.Sub(previous.timestamp).Hours()
+	// Counters reset when the pod sandbox restarts
+	if !ok || hours <= 0 || current.txBytes < previous.txBytes || current.rxBytes < previous.rxBytes {
+		return 0, 0
+	}
+
+	return float64(current.txBytes-previous.txBytes) / hours, float64(current.rxBytes-previous.rxBytes) / hours
+}
+
+// isHostNetwork reports whether a pod uses the host network, whose traffic the
+// kubelet reports for the whole node
+func (kc *KubeletCollector) isHostNetwork(pod *kubeletPodStats) bool {
+	p, err := kc.informers.Pods.Pods(pod.PodRef.Namespace).Get(pod.PodRef.Name)
+	return err == nil && p.Spec.HostNetwork
+}
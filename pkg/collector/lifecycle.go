@@ -0,0 +1,178 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+// PodRun is the runtime of a pod that has finished or been deleted
+type PodRun struct {
+	Pod   PodInfo
+	Start time.Time
+	End   time.Time
+}
+
+// PodLifecycleTracker watches pod events for pods that finish or are deleted, so
+// pods that start and finish between collection cycles are still costed for their
+// exact runtime
+type PodLifecycleTracker struct {
+	pods   *PodCollector
+	logger *logrus.Logger
+
+	mu       sync.Mutex
+	finished []finishedPod
+	recorded map[types.UID]bool // pods whose run has been recorded
+}
+
+// finishedPod is a finished pod and when it ended, until its run is taken
+type finishedPod struct {
+	pod *corev1.Pod
+	end time.Time
+}
+
+// NewPodLifecycleTracker creates a new pod lifecycle tracker and registers it for
+// pod events. Pods are described by the pod collector.
+func NewPodLifecycleTracker(informers *InformerCache, pods *PodCollector) (*PodLifecycleTracker, error) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+
+	pt := &PodLifecycleTracker{
+		pods:     pods,
+		logger:   logger,
+		recorded: make(map[types.UID]bool),
+	}
+
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				pt.podUpdated(pod)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if pod, ok := newObj.(*corev1.Pod); ok {
+				pt.podUpdated(pod)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if pod, ok := obj.(*corev1.Pod); ok {
+				pt.podDeleted(pod)
+			}
+		},
+	}
+	if err := informers.AddPodEventHandler(handler); err != nil {
+		return nil, fmt.Errorf("failed to add pod lifecycle event handler: %w", err)
+	}
+
+	return pt, nil
+}
+
+// TakeFinished returns the runs of pods that finished or were deleted since the
+// previous call
+func (pt *PodLifecycleTracker) TakeFinished(ctx context.Context) []PodRun {
+	pt.mu.Lock()
+	finished := pt.finished
+	pt.finished = nil
+	pt.mu.Unlock()
+
+	runs := make([]PodRun, 0, len(finished))
+	for _, f := range finished {
+		start := podStartTime(f.pod)
+		if start.IsZero() || !f.end.After(start) {
+			continue
+		}
+		runs = append(runs, PodRun{
+			Pod:   pt.pods.extractPodInfo(ctx, f.pod),
+			Start: start,
+			End:   f.end,
+		})
+	}
+
+	return runs
+}
+
+// podUpdated records the run of a pod that reached a terminal phase
+func (pt *PodLifecycleTracker) podUpdated(pod *corev1.Pod) {
+	if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+		return
+	}
+	pt.record(pod, podEndTime(pod))
+}
+
+// podDeleted records the run of a deleted pod that was still running, and forgets
+// the pod
+func (pt *PodLifecycleTracker) podDeleted(pod *corev1.Pod) {
+	end := podEndTime(pod)
+	if end.IsZero() {
+		end = time.Now()
+	}
+	pt.record(pod, end)
+
+	pt.mu.Lock()
+	delete(pt.recorded, pod.UID)
+	pt.mu.Unlock()
+}
+
+// record queues a pod's run unless it was already recorded. Pods that never ran
+// on a node are skipped.
+func (pt *PodLifecycleTracker) record(pod *corev1.Pod, end time.Time) {
+	if pod.Spec.NodeName == "" {
+		return
+	}
+
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	if pt.recorded[pod.UID] {
+		return
+	}
+	pt.recorded[pod.UID] = true
+	pt.finished = append(pt.finished, finishedPod{pod: pod, end: end})
+}
+
+// podStartTime returns when a pod's first container started, or when the kubelet
+// accepted the pod if no container has started
+func podStartTime(pod *corev1.Pod) time.Time {
+	var start time.Time
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		var started time.Time
+		switch {
+		case status.State.Running != nil:
+			started = status.State.Running.StartedAt.Time
+		case status.State.Terminated != nil:
+			started = status.State.Terminated.StartedAt.Time
+		}
+		if !started.IsZero() && (start.IsZero() || started.Before(start)) {
+			start = started
+		}
+	}
+
+	if start.IsZero() && pod.Status.StartTime != nil {
+		start = pod.Status.StartTime.Time
+	}
+	return start
+}
+
+// podEndTime returns when a pod's last container finished, or zero if any
+// container has not terminated
+func podEndTime(pod *corev1.Pod) time.Time {
+	var end time.Time
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated == nil {
+			return time.Time{}
+		}
+		if finished := status.State.Terminated.FinishedAt.Time; finished.After(end) {
+			end = finished
+		}
+	}
+	return end
+}
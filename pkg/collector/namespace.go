@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// NamespaceCollector collects namespace labels and annotations for cost aggregation
+type NamespaceCollector struct {
+	informers *InformerCache
+	logger    *logrus.Logger
+}
+
+// NewNamespaceCollector creates a new namespace collector
+func NewNamespaceCollector(informers *InformerCache) *NamespaceCollector {
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+
+	return &NamespaceCollector{
+		informers: informers,
+		logger:    logger,
+	}
+}
+
+// NamespaceInfo contains the labels and annotations of a namespace
+type NamespaceInfo struct {
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// CollectNamespaces collects all namespaces in the cluster
+func (nc *NamespaceCollector) CollectNamespaces() ([]NamespaceInfo, error) {
+	namespaces, err := nc.informers.Namespaces.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	namespaceInfos := make([]NamespaceInfo, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		namespaceInfos = append(namespaceInfos, NamespaceInfo{
+			Name:        namespace.Name,
+			Labels:      namespace.Labels,
+			Annotations: namespace.Annotations,
+		})
+	}
+
+	return namespaceInfos, nil
+}
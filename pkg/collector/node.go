@@ -3,86 +3,224 @@ package collector
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/deepcost/kube-cost-exporter/pkg/config"
 	"github.com/deepcost/kube-cost-exporter/pkg/pricing"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // NodeCollector collects node information and pricing
 type NodeCollector struct {
-	clientset      *kubernetes.Clientset
-	pricingCache   *pricing.PricingCache
-	cloudProvider  string
-	region         string
-	logger         *logrus.Logger
+	informers   *InformerCache
+	registry    *pricing.Registry
+	region      string
+	rootVolumes map[string]config.RootVolume // by node pool, "" for the default
+	logger      *logrus.Logger
+
+	mu    sync.Mutex
+	nodes map[string]NodeInfo // priced nodes by name, recalculated when they change
 }
 
-// NewNodeCollector creates a new node collector
-func NewNodeCollector(clientset *kubernetes.Clientset, pricingCache *pricing.PricingCache, cloudProvider, region string) *NodeCollector {
+// NewNodeCollector creates a new node collector. Each node is priced with the
+// provider detected from its provider ID and labels, falling back to the
+// registry's default provider and the configured region. Root volumes are priced
+// by the configured root volume of the node's pool.
+func NewNodeCollector(informers *InformerCache, registry *pricing.Registry, region string, rootVolumes []config.RootVolume) *NodeCollector {
 	logger := logrus.New()
 	logger.SetLevel(logrus.InfoLevel)
 
+	volumes := make(map[string]config.RootVolume, len(rootVolumes))
+	for _, volume := range rootVolumes {
+		volumes[volume.NodePool] = volume
+	}
+
 	return &NodeCollector{
-		clientset:     clientset,
-		pricingCache:  pricingCache,
-		cloudProvider: cloudProvider,
-		region:        region,
-		logger:        logger,
+		informers:   informers,
+		registry:    registry,
+		region:      region,
+		rootVolumes: volumes,
+		logger:      logger,
+		nodes:       make(map[string]NodeInfo),
 	}
 }
 
+// Pricing sources recorded on NodeInfo
+const (
+	PricingSourceList      = "list"      // price list lookup of the node's instance type
+	PricingSourceFlexible  = "flexible"  // flexible shape priced by its configured size
+	PricingSourceInferred  = "inferred"  // nearest catalog instance type to the node's capacity
+	PricingSourceComponent = "component" // per-vCPU, per-GiB and per-GPU rates
+)
+
 // NodeInfo contains information about a node and its pricing
 type NodeInfo struct {
-	Name             string
-	InstanceType     string
-	Region           string
-	AvailabilityZone string
-	IsSpot           bool
-	HourlyPrice      float64
-	CPUCapacity      int64  // millicores
-	MemoryCapacity   int64  // bytes
-	Labels           map[string]string
-}
-
-// CollectNodes collects all nodes and their pricing information
+	Name              string
+	CloudProvider     string
+	InstanceType      string
+	Region            string
+	AvailabilityZone  string
+	NodePool          string
+	IsSpot            bool
+	HourlyPrice       float64 // effective price after discounts
+	ListPrice         float64 // on-demand or spot list price before discounts
+	CPUCapacity       int64   // millicores
+	MemoryCapacity    int64   // bytes
+	CPUAllocatable    int64   // millicores, capacity minus system reservations
+	MemoryAllocatable int64   // bytes
+	GPUCapacity       int64
+	Architecture      string // amd64 or arm64
+	PricingSource     string
+	Labels            map[string]string
+	Taints            []corev1.Taint
+	CreationTime      time.Time
+
+	// Closest arm64 instance type and its price, for amd64 nodes
+	Arm64EquivalentType  string
+	Arm64EquivalentPrice float64
+
+	// Internet egress price, used for kubelet network statistics
+	NetworkPricePerGB float64
+
+	// Root volume type, size and price, and the ephemeral storage it provides to
+	// pods, used to price pod ephemeral storage
+	RootVolumeType              string
+	RootVolumeSizeGB            float64
+	RootVolumePricePerGBMonth   float64
+	EphemeralStorageCapacity    int64 // bytes
+	EphemeralStorageAllocatable int64 // bytes
+
+	// Provider per-resource rates, if available, used to split the node price
+	// between CPU, memory and GPUs
+	ComponentRates pricing.ComponentRates
+}
+
+// RootVolumeHourlyCost returns the hourly cost of the node's root volume, which is
+// not included in its price
+func (n NodeInfo) RootVolumeHourlyCost() float64 {
+	return n.RootVolumeSizeGB * n.RootVolumePricePerGBMonth / 730
+}
+
+// TotalHourlyCost returns the hourly cost of the node including its root volume
+func (n NodeInfo) TotalHourlyCost() float64 {
+	return n.HourlyPrice + n.RootVolumeHourlyCost()
+}
+
+// CollectNodes collects all nodes and their pricing information. Only nodes that
+// changed since the last collection are repriced.
 func (nc *NodeCollector) CollectNodes(ctx context.Context) ([]NodeInfo, error) {
-	nodes, err := nc.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list nodes: %w", err)
-	}
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
 
-	var nodeInfos []NodeInfo
-	for _, node := range nodes.Items {
-		nodeInfo, err := nc.collectNodeInfo(ctx, &node)
+	changes, full := nc.informers.Changes().TakeChanges(KindNode)
+	if full {
+		nodes, err := nc.informers.Nodes.List(labels.Everything())
 		if err != nil {
-			nc.logger.Warnf("Failed to collect info for node %s: %v", node.Name, err)
-			continue
+			return nil, fmt.Errorf("failed to list nodes: %w", err)
 		}
+
+		nc.nodes = make(map[string]NodeInfo, len(nodes))
+		for _, node := range nodes {
+			nc.updateNode(ctx, node)
+		}
+		nc.logger.Debugf("Repriced all %d nodes", len(nodes))
+	} else {
+		for name, deleted := range changes {
+			if deleted {
+				delete(nc.nodes, name)
+				continue
+			}
+			node, err := nc.informers.Nodes.Get(name)
+			if err != nil {
+				// Deleted after the change was recorded
+				delete(nc.nodes, name)
+				continue
+			}
+			nc.updateNode(ctx, node)
+		}
+		nc.logger.Debugf("Repriced %d changed nodes", len(changes))
+	}
+
+	nodeInfos := make([]NodeInfo, 0, len(nc.nodes))
+	for _, nodeInfo := range nc.nodes {
 		nodeInfos = append(nodeInfos, nodeInfo)
 	}
+	sort.Slice(nodeInfos, func(i, j int) bool {
+		return nodeInfos[i].Name < nodeInfos[j].Name
+	})
 
 	return nodeInfos, nil
 }
 
+// updateNode reprices a node and stores it in the node cache
+func (nc *NodeCollector) updateNode(ctx context.Context, node *corev1.Node) {
+	nodeInfo, err := nc.collectNodeInfo(ctx, node)
+	if err != nil {
+		nc.logger.Warnf("Failed to collect info for node %s: %v", node.Name, err)
+		delete(nc.nodes, node.Name)
+		return
+	}
+	nc.nodes[node.Name] = nodeInfo
+}
+
 // collectNodeInfo extracts pricing information for a single node
 func (nc *NodeCollector) collectNodeInfo(ctx context.Context, node *corev1.Node) (NodeInfo, error) {
-	instanceType := nc.getInstanceType(node)
 	region := nc.getRegion(node)
 	az := nc.getAvailabilityZone(node)
+	nodePool := nc.getNodePool(node)
 	isSpot := nc.isSpotInstance(node)
 
+	provider, pricingCache := nc.registry.Resolve(detectNodeProvider(node))
+	if pricingCache == nil {
+		return NodeInfo{}, fmt.Errorf("no pricing provider registered for %q", provider)
+	}
+	instanceType := nc.getInstanceType(node, pricingCache.InstanceCatalog())
+
+	// Get capacity
+	cpuCapacity := node.Status.Capacity.Cpu().MilliValue()
+	memoryCapacity := node.Status.Capacity.Memory().Value()
+	cpuAllocatable := node.Status.Allocatable.Cpu().MilliValue()
+	memoryAllocatable := node.Status.Allocatable.Memory().Value()
+	gpuCapacity := getGPUCapacity(node)
+	ephemeralCapacity := node.Status.Capacity.StorageEphemeral().Value()
+	ephemeralAllocatable := node.Status.Allocatable.StorageEphemeral().Value()
+
 	// Get pricing
+	vcpus := float64(cpuCapacity) / 1000
+	memoryGiB := float64(memoryCapacity) / (1024 * 1024 * 1024)
+	arch := getArchitecture(node)
 	var hourlyPrice float64
+	var pricingSource string
 	var err error
-
-	if isSpot {
-		hourlyPrice, err = nc.pricingCache.GetSpotPrice(ctx, instanceType, region, az)
+	if instanceType == "unknown" {
+		err = fmt.Errorf("instance type not found in labels or provider ID")
 	} else {
-		hourlyPrice, err = nc.pricingCache.GetInstancePrice(ctx, instanceType, region, az)
+		hourlyPrice, pricingSource, err = nc.priceInstance(ctx, pricingCache, instanceType, region, az, vcpus, memoryGiB, isSpot)
+	}
+
+	// Price nodes without a known instance type by their shape
+	if err != nil {
+		shape := pricing.InstanceShape{
+			VCPUs:        vcpus,
+			MemoryGiB:    memoryGiB,
+			GPUs:         int(gpuCapacity),
+			Architecture: arch,
+		}
+		inferredType, price, source, inferErr := nc.priceByShape(ctx, pricingCache, shape, region, az, isSpot)
+		if inferErr != nil {
+			nc.logger.Debugf("Failed to infer price for node %s: %v", node.Name, inferErr)
+		} else {
+			nc.logger.Debugf("Priced node %s by shape (%s, matched %q) after: %v", node.Name, source, inferredType, err)
+			if inferredType != "" {
+				instanceType = inferredType
+			}
+			hourlyPrice, pricingSource, err = price, source, nil
+		}
 	}
 
 	if err != nil {
@@ -90,25 +228,149 @@ func (nc *NodeCollector) collectNodeInfo(ctx context.Context, node *corev1.Node)
 		hourlyPrice = 0.0
 	}
 
-	// Get capacity
-	cpuCapacity := node.Status.Capacity.Cpu().MilliValue()
-	memoryCapacity := node.Status.Capacity.Memory().Value()
+	// Component rates weight the node price between CPU, memory and GPUs
+	componentRates, ok, err := pricingCache.GetComponentRates(ctx, region)
+	if err != nil || !ok {
+		componentRates = pricing.ComponentRates{}
+	}
+
+	// Price the closest arm64 instance type for amd64 nodes
+	var arm64Type string
+	var arm64Price float64
+	if arch == "amd64" && gpuCapacity == 0 && hourlyPrice > 0 {
+		if equivalent, ok := pricing.Arm64Equivalent(provider, instanceType, pricingCache.InstanceCatalog(), vcpus, memoryGiB); ok {
+			price, _, err := nc.priceInstance(ctx, pricingCache, equivalent, region, az, vcpus, memoryGiB, isSpot)
+			if err != nil {
+				nc.logger.Debugf("Failed to price arm64 equivalent %s for node %s: %v", equivalent, node.Name, err)
+			} else {
+				arm64Type, arm64Price = equivalent, price
+			}
+		}
+	}
+
+	networkPrice, err := pricingCache.GetNetworkPrice(ctx, region, "internet")
+	if err != nil {
+		nc.logger.Debugf("Failed to get network price for node %s: %v", node.Name, err)
+	}
+	rootVolumeType, rootVolumeSizeGB := nc.getRootVolume(provider, nodePool, ephemeralCapacity)
+	var rootVolumePrice float64
+	if rootVolumeType != "" {
+		rootVolumePrice, err = pricingCache.GetStoragePrice(ctx, rootVolumeType, region)
+		if err != nil {
+			nc.logger.Debugf("Failed to get root volume price for node %s: %v", node.Name, err)
+		}
+	}
 
 	return NodeInfo{
-		Name:             node.Name,
-		InstanceType:     instanceType,
-		Region:           region,
-		AvailabilityZone: az,
-		IsSpot:           isSpot,
-		HourlyPrice:      hourlyPrice,
-		CPUCapacity:      cpuCapacity,
-		MemoryCapacity:   memoryCapacity,
-		Labels:           node.Labels,
+		Name:              node.Name,
+		CloudProvider:     provider,
+		InstanceType:      instanceType,
+		Region:            region,
+		AvailabilityZone:  az,
+		NodePool:          nodePool,
+		IsSpot:            isSpot,
+		HourlyPrice:       hourlyPrice,
+		ListPrice:         hourlyPrice,
+		CPUCapacity:       cpuCapacity,
+		MemoryCapacity:    memoryCapacity,
+		CPUAllocatable:    cpuAllocatable,
+		MemoryAllocatable: memoryAllocatable,
+		GPUCapacity:       gpuCapacity,
+		Architecture:      arch,
+		PricingSource:     pricingSource,
+		Labels:            node.Labels,
+		Taints:            node.Spec.Taints,
+		CreationTime:      node.CreationTimestamp.Time,
+
+		Arm64EquivalentType:  arm64Type,
+		Arm64EquivalentPrice: arm64Price,
+
+		NetworkPricePerGB: networkPrice,
+
+		RootVolumeType:              rootVolumeType,
+		RootVolumeSizeGB:            rootVolumeSizeGB,
+		RootVolumePricePerGBMonth:   rootVolumePrice,
+		EphemeralStorageCapacity:    ephemeralCapacity,
+		EphemeralStorageAllocatable: ephemeralAllocatable,
+
+		ComponentRates: componentRates,
 	}, nil
 }
 
-// getInstanceType extracts the instance type from node labels
-func (nc *NodeCollector) getInstanceType(node *corev1.Node) string {
+// rootVolumeTypes maps providers to the default volume type of node root disks.
+// DigitalOcean, Linode and Hetzner include local disks in the node price.
+var rootVolumeTypes = map[string]string{
+	"aws":    "gp3",
+	"gcp":    "pd-balanced",
+	"azure":  "StandardSSD_LRS",
+	"oracle": "oci-bv",
+}
+
+// getRootVolume returns the type and size in GB of a node's root volume: the
+// configured root volume of its node pool, then the default configured root
+// volume, then the provider's default volume type and the size of the node's
+// ephemeral storage. Nodes without a volume type have no separately priced root
+// volume.
+func (nc *NodeCollector) getRootVolume(provider, nodePool string, ephemeralCapacity int64) (string, float64) {
+	volumeType := rootVolumeTypes[provider]
+	sizeGB := float64(ephemeralCapacity) / (1000 * 1000 * 1000)
+
+	for _, pool := range []string{"", nodePool} {
+		volume, ok := nc.rootVolumes[pool]
+		if !ok {
+			continue
+		}
+		if volume.Type != "" {
+			volumeType = volume.Type
+		}
+		if volume.SizeGB > 0 {
+			sizeGB = volume.SizeGB
+		}
+	}
+
+	return volumeType, sizeGB
+}
+
+// priceInstance returns the hourly price of an instance type and its pricing
+// source. Flexible shapes are priced by the node's actual size.
+func (nc *NodeCollector) priceInstance(ctx context.Context, pricingCache *pricing.PricingCache, instanceType, region, az string, vcpus, memoryGiB float64, isSpot bool) (float64, string, error) {
+	price, flexible, err := pricingCache.GetFlexiblePrice(ctx, instanceType, region, vcpus, memoryGiB, isSpot)
+	if flexible {
+		return price, PricingSourceFlexible, err
+	}
+	if isSpot {
+		price, err = pricingCache.GetSpotPrice(ctx, instanceType, region, az)
+	} else {
+		price, err = pricingCache.GetInstancePrice(ctx, instanceType, region, az)
+	}
+	return price, PricingSourceList, err
+}
+
+// priceByShape prices a node from its capacity. It first looks for the nearest
+// instance type in the provider's catalog, then falls back to component rates.
+// Returns the inferred instance type, or an empty string for component pricing.
+func (nc *NodeCollector) priceByShape(ctx context.Context, pricingCache *pricing.PricingCache, shape pricing.InstanceShape, region, az string, isSpot bool) (string, float64, string, error) {
+	if match, ok := pricing.NearestInstanceShape(pricingCache.InstanceCatalog(), shape.VCPUs, shape.MemoryGiB, shape.GPUs, shape.Architecture); ok {
+		if price, _, err := nc.priceInstance(ctx, pricingCache, match.Name, region, az, shape.VCPUs, shape.MemoryGiB, isSpot); err == nil {
+			return match.Name, price, PricingSourceInferred, nil
+		}
+	}
+
+	// Component rates are on-demand, so spot nodes priced this way are overestimated
+	rates, ok, err := pricingCache.GetComponentRates(ctx, region)
+	if err != nil {
+		return "", 0, "", fmt.Errorf("failed to get component rates: %w", err)
+	}
+	if !ok {
+		return "", 0, "", fmt.Errorf("no catalog match and no component rates")
+	}
+	price := shape.VCPUs*rates.CPUHourly + shape.MemoryGiB*rates.MemoryGiBHourly + float64(shape.GPUs)*rates.GPUHourly
+	return "", price, PricingSourceComponent, nil
+}
+
+// getInstanceType extracts the instance type from node labels. A type parsed from
+// the provider ID is only used if it appears in the provider's catalog.
+func (nc *NodeCollector) getInstanceType(node *corev1.Node, catalog []pricing.InstanceShape) string {
 	// Try different label keys used by different cloud providers
 	labelKeys := []string{
 		"node.kubernetes.io/instance-type",
@@ -122,11 +384,13 @@ func (nc *NodeCollector) getInstanceType(node *corev1.Node) string {
 		}
 	}
 
-	// Fallback: try to parse from provider ID
-	if node.Spec.ProviderID != "" {
+	// Fallback: try to parse from provider ID. Skip providers whose IDs end in an
+	// instance ID (e.g. digitalocean://12345) rather than an instance type.
+	if node.Spec.ProviderID != "" && !instanceIDProviders[providerFromID(node.Spec.ProviderID)] {
 		parts := strings.Split(node.Spec.ProviderID, "/")
-		if len(parts) > 0 {
-			return parts[len(parts)-1]
+		fragment := parts[len(parts)-1]
+		if len(catalog) == 0 || pricing.HasInstanceType(catalog, fragment) {
+			return fragment
 		}
 	}
 
@@ -167,6 +431,55 @@ func (nc *NodeCollector) getAvailabilityZone(node *corev1.Node) string {
 	return ""
 }
 
+// getNodePool extracts the node pool (node group, agent pool, Karpenter NodePool)
+// from the well-known labels of each platform
+func (nc *NodeCollector) getNodePool(node *corev1.Node) string {
+	labelKeys := []string{
+		"karpenter.sh/nodepool",
+		"karpenter.sh/provisioner-name", // Karpenter before v1beta1
+		"eks.amazonaws.com/nodegroup",
+		"alpha.eksctl.io/nodegroup-name",
+		"cloud.google.com/gke-nodepool",
+		"kubernetes.azure.com/agentpool",
+		"agentpool",
+		"doks.digitalocean.com/node-pool",
+		"lke.linode.com/pool-id",
+		"hcloud/node-group", // cluster-autoscaler on Hetzner Cloud
+	}
+
+	for _, key := range labelKeys {
+		if pool, ok := node.Labels[key]; ok {
+			return pool
+		}
+	}
+
+	return "none"
+}
+
+// getArchitecture returns the node's CPU architecture (amd64, arm64)
+func getArchitecture(node *corev1.Node) string {
+	for _, key := range []string{"kubernetes.io/arch", "beta.kubernetes.io/arch"} {
+		if arch, ok := node.Labels[key]; ok {
+			return arch
+		}
+	}
+	return node.Status.NodeInfo.Architecture
+}
+
+// gpuResources are the extended resources GPU device plugins advertise
+var gpuResources = []corev1.ResourceName{"nvidia.com/gpu", "amd.com/gpu"}
+
+// getGPUCapacity returns the number of GPUs advertised by device plugins
+func getGPUCapacity(node *corev1.Node) int64 {
+	var gpus int64
+	for _, resource := range gpuResources {
+		if quantity, ok := node.Status.Capacity[resource]; ok {
+			gpus += quantity.Value()
+		}
+	}
+	return gpus
+}
+
 // isSpotInstance determines if a node is a spot/preemptible instance
 func (nc *NodeCollector) isSpotInstance(node *corev1.Node) bool {
 	// Check various labels that indicate spot instances
@@ -175,6 +488,8 @@ func (nc *NodeCollector) isSpotInstance(node *corev1.Node) bool {
 		"eks.amazonaws.com/capacityType",
 		"cloud.google.com/gke-preemptible",
 		"kubernetes.azure.com/scalesetpriority",
+		"oci.oraclecloud.com/oke-is-preemptible",
+		"node.kubernetes.io/capacity-type",
 	}
 
 	for _, label := range spotLabels {
@@ -0,0 +1,183 @@
+package collector
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// maxOwnerDepth limits how many owner references are followed from a pod
+const maxOwnerDepth = 5
+
+// ownerCacheTTL is how long resolved owner references are cached. Owners rarely
+// change, so this mostly bounds how long deleted owners stay cached.
+const ownerCacheTTL = 30 * time.Minute
+
+// ownerKey identifies an owner object
+type ownerKey struct {
+	namespace  string
+	apiVersion string
+	kind       string
+	name       string
+}
+
+// ownerCacheEntry caches the controller of an owner object, if it has one
+type ownerCacheEntry struct {
+	controller *metav1.OwnerReference
+	expiresAt  time.Time
+}
+
+// OwnerResolver resolves pods to their top-level workload by following controller
+// owner references, e.g. Pod -> ReplicaSet -> Deployment or Pod -> Job -> CronJob.
+// ReplicaSets and Jobs are read from the informer cache; other owners, including
+// custom resources such as Argo Rollouts, are read with the dynamic client.
+type OwnerResolver struct {
+	informers *InformerCache
+	dynamic   dynamic.Interface
+	mapper    *restmapper.DeferredDiscoveryRESTMapper
+	logger    *logrus.Logger
+
+	mu        sync.Mutex
+	cache     map[ownerKey]ownerCacheEntry
+	lastPrune time.Time
+}
+
+// NewOwnerResolver creates a new owner resolver
+func NewOwnerResolver(informers *InformerCache, dynamicClient dynamic.Interface, discoveryClient discovery.DiscoveryInterface) *OwnerResolver {
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+
+	return &OwnerResolver{
+		informers: informers,
+		dynamic:   dynamicClient,
+		mapper:    restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+		logger:    logger,
+		cache:     make(map[ownerKey]ownerCacheEntry),
+	}
+}
+
+// Resolve returns the kind and name of the top-level controller of an object with
+// the given owner references. Owners that cannot be read end the chain.
+func (r *OwnerResolver) Resolve(ctx context.Context, namespace string, ownerReferences []metav1.OwnerReference) (string, string, bool) {
+	owner := controllerOf(ownerReferences)
+	if owner == nil {
+		return "", "", false
+	}
+
+	for depth := 0; depth < maxOwnerDepth; depth++ {
+		next := r.controllerOf(ctx, namespace, owner)
+		if next == nil {
+			break
+		}
+		owner = next
+	}
+
+	return owner.Kind, owner.Name, true
+}
+
+// controllerOf returns the controller of an owner object, using the cache
+func (r *OwnerResolver) controllerOf(ctx context.Context, namespace string, owner *metav1.OwnerReference) *metav1.OwnerReference {
+	// Core objects (e.g. the Node that owns a static pod) are never owned by a workload
+	if owner.APIVersion == "v1" {
+		return nil
+	}
+
+	key := ownerKey{namespace, owner.APIVersion, owner.Kind, owner.Name}
+
+	r.mu.Lock()
+	entry, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.controller
+	}
+
+	controller := r.lookupController(ctx, namespace, owner)
+
+	r.mu.Lock()
+	r.cache[key] = ownerCacheEntry{
+		controller: controller,
+		expiresAt:  time.Now().Add(ownerCacheTTL),
+	}
+	r.pruneLocked()
+	r.mu.Unlock()
+
+	return controller
+}
+
+// lookupController reads an owner object and returns its controller reference
+func (r *OwnerResolver) lookupController(ctx context.Context, namespace string, owner *metav1.OwnerReference) *metav1.OwnerReference {
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		return nil
+	}
+
+	switch {
+	case gv.Group == "apps" && owner.Kind == "ReplicaSet":
+		if rs, err := r.informers.ReplicaSets.ReplicaSets(namespace).Get(owner.Name); err == nil {
+			return controllerOf(rs.OwnerReferences)
+		}
+	case gv.Group == "batch" && owner.Kind == "Job":
+		if job, err := r.informers.Jobs.Jobs(namespace).Get(owner.Name); err == nil {
+			return controllerOf(job.OwnerReferences)
+		}
+	}
+
+	// Not cached by an informer, e.g. a Deployment or a custom resource
+	mapping, err := r.mapper.RESTMapping(gv.WithKind(owner.Kind).GroupKind(), gv.Version)
+	if err != nil {
+		r.logger.Debugf("Failed to map owner %s %s: %v", owner.APIVersion, owner.Kind, err)
+		return nil
+	}
+
+	var resource dynamic.ResourceInterface = r.dynamic.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		resource = r.dynamic.Resource(mapping.Resource).Namespace(namespace)
+	}
+
+	obj, err := resource.Get(ctx, owner.Name, metav1.GetOptions{})
+	if err != nil {
+		r.logger.Debugf("Failed to get owner %s %s/%s: %v", owner.Kind, namespace, owner.Name, err)
+		return nil
+	}
+
+	return controllerOf(obj.GetOwnerReferences())
+}
+
+// pruneLocked removes expired entries, at most once per TTL. Callers must hold r.mu.
+func (r *OwnerResolver) pruneLocked() {
+	now := time.Now()
+	if now.Sub(r.lastPrune) < ownerCacheTTL {
+		return
+	}
+	for key, entry := range r.cache {
+		if now.After(entry.expiresAt) {
+			delete(r.cache, key)
+		}
+	}
+	r.lastPrune = now
+}
+
+// controllerOf returns a copy of the controller owner reference, or of the first
+// owner if none is marked as the controller
+func controllerOf(ownerReferences []metav1.OwnerReference) *metav1.OwnerReference {
+	if len(ownerReferences) == 0 {
+		return nil
+	}
+	owner := ownerReferences[0]
+	for _, ref := range ownerReferences {
+		if ref.Controller != nil && *ref.Controller {
+			owner = ref
+			break
+		}
+	}
+	return &owner
+}
@@ -3,27 +3,36 @@ package collector
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
 )
 
 // PodCollector collects pod information for cost calculation
 type PodCollector struct {
-	clientset *kubernetes.Clientset
+	informers *InformerCache
+	owners    *OwnerResolver
 	logger    *logrus.Logger
+
+	mu   sync.Mutex
+	pods map[string]PodInfo // running and pending pods by namespace/name
 }
 
-// NewPodCollector creates a new pod collector
-func NewPodCollector(clientset *kubernetes.Clientset) *PodCollector {
+// NewPodCollector creates a new pod collector. Pods are attributed to the
+// top-level workload found by the owner resolver.
+func NewPodCollector(informers *InformerCache, owners *OwnerResolver) *PodCollector {
 	logger := logrus.New()
 	logger.SetLevel(logrus.InfoLevel)
 
 	return &PodCollector{
-		clientset: clientset,
+		informers: informers,
+		owners:    owners,
 		logger:    logger,
+		pods:      make(map[string]PodInfo),
 	}
 }
 
@@ -31,54 +40,119 @@ func NewPodCollector(clientset *kubernetes.Clientset) *PodCollector {
 type PodInfo struct {
 	Name              string
 	Namespace         string
+	UID               string
 	NodeName          string
 	CPURequest        int64 // millicores
 	MemoryRequest     int64 // bytes
 	CPULimit          int64 // millicores
 	MemoryLimit       int64 // bytes
+	GPURequest        int64
+	CPUUsage          int64 // millicores, averaged over the usage window
+	MemoryUsage       int64 // bytes, averaged over the usage window
+	HasUsage          bool
 	Labels            map[string]string
 	OwnerKind         string
 	OwnerName         string
+	StartTime         time.Time
+
+	// Ephemeral storage requested by the pod's containers
+	EphemeralStorageRequest int64 // bytes
+
+	// Requests and limits of the pod's app containers and native sidecars. The
+	// pod's requests also include its init containers and pod overhead.
+	ContainerResources []ContainerResources
+	CPUOverhead        int64 // millicores
+	MemoryOverhead     int64 // bytes
+
+	// Kubelet statistics, set when the kubelet summary collector is enabled
+	Containers               []ContainerUsage
+	EphemeralStorageUsage    int64   // bytes, including emptyDir volumes
+	NetworkTxBytesPerHour    float64 // bytes
+	NetworkRxBytesPerHour    float64 // bytes
+	HasEphemeralStorageUsage bool
+
+	// Scheduling constraints and creation time, used to price pods that are not
+	// scheduled yet
+	CreationTime time.Time
+	NodeSelector map[string]string
+	NodeAffinity *corev1.NodeSelector // required node affinity
+	Tolerations  []corev1.Toleration
+
+	// Persistent volume claims the pod mounts
+	PVCs []string
 }
 
-// CollectPods collects all pods in the cluster
+// CollectPods collects all pods in the cluster. Only pods that changed since the
+// last collection are re-read.
 func (pc *PodCollector) CollectPods(ctx context.Context) ([]PodInfo, error) {
-	pods, err := pc.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
-	}
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	changes, full := pc.informers.Changes().TakeChanges(KindPod)
+	if full {
+		pods, err := pc.informers.Pods.List(labels.Everything())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
 
-	var podInfos []PodInfo
-	for _, pod := range pods.Items {
-		// Skip pods that are not running or scheduled
-		if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
-			continue
+		pc.pods = make(map[string]PodInfo, len(pods))
+		for _, pod := range pods {
+			pc.updatePod(ctx, pod)
+		}
+	} else {
+		for key, deleted := range changes {
+			namespace, name, err := cache.SplitMetaNamespaceKey(key)
+			if err != nil {
+				continue
+			}
+			if deleted {
+				delete(pc.pods, key)
+				continue
+			}
+			pod, err := pc.informers.Pods.Pods(namespace).Get(name)
+			if err != nil {
+				delete(pc.pods, key)
+				continue
+			}
+			pc.updatePod(ctx, pod)
 		}
+	}
 
-		podInfo := pc.extractPodInfo(&pod)
+	podInfos := make([]PodInfo, 0, len(pc.pods))
+	for _, podInfo := range pc.pods {
 		podInfos = append(podInfos, podInfo)
 	}
 
 	return podInfos, nil
 }
 
+// updatePod stores a running or pending pod in the pod cache, removing it otherwise
+func (pc *PodCollector) updatePod(ctx context.Context, pod *corev1.Pod) {
+	key := pod.Namespace + "/" + pod.Name
+
+	// Skip pods that are not running or scheduled
+	if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
+		delete(pc.pods, key)
+		return
+	}
+
+	pc.pods[key] = pc.extractPodInfo(ctx, pod)
+}
+
 // CollectPodsOnNode collects pods running on a specific node
 func (pc *PodCollector) CollectPodsOnNode(ctx context.Context, nodeName string) ([]PodInfo, error) {
-	fieldSelector := fmt.Sprintf("spec.nodeName=%s", nodeName)
-	pods, err := pc.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
-		FieldSelector: fieldSelector,
-	})
+	pods, err := pc.informers.PodsOnNode(nodeName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods on node %s: %w", nodeName, err)
 	}
 
 	var podInfos []PodInfo
-	for _, pod := range pods.Items {
+	for _, pod := range pods {
 		if pod.Status.Phase != corev1.PodRunning {
 			continue
 		}
 
-		podInfo := pc.extractPodInfo(&pod)
+		podInfo := pc.extractPodInfo(ctx, pod)
 		podInfos = append(podInfos, podInfo)
 	}
 
@@ -86,97 +160,94 @@ func (pc *PodCollector) CollectPodsOnNode(ctx context.Context, nodeName string)
 }
 
 // extractPodInfo extracts relevant information from a pod
-func (pc *PodCollector) extractPodInfo(pod *corev1.Pod) PodInfo {
+func (pc *PodCollector) extractPodInfo(ctx context.Context, pod *corev1.Pod) PodInfo {
 	cpuRequest, memoryRequest := pc.getPodRequests(pod)
 	cpuLimit, memoryLimit := pc.getPodLimits(pod)
-	ownerKind, ownerName := pc.getPodOwner(pod)
+	gpuRequest := pc.getPodGPURequest(pod)
+	ownerKind, ownerName := pc.getPodOwner(ctx, pod)
 
 	return PodInfo{
 		Name:          pod.Name,
 		Namespace:     pod.Namespace,
+		UID:           string(pod.UID),
 		NodeName:      pod.Spec.NodeName,
 		CPURequest:    cpuRequest,
 		MemoryRequest: memoryRequest,
 		CPULimit:      cpuLimit,
 		MemoryLimit:   memoryLimit,
+		GPURequest:    gpuRequest,
+		StartTime:     podStartTime(pod),
 		Labels:        pod.Labels,
 		OwnerKind:     ownerKind,
 		OwnerName:     ownerName,
-	}
-}
+		CreationTime:  pod.CreationTimestamp.Time,
+		NodeSelector:  pod.Spec.NodeSelector,
+		NodeAffinity:  requiredNodeAffinity(pod),
+		Tolerations:   pod.Spec.Tolerations,
+		PVCs:          podClaimNames(pod),
 
-// getPodRequests calculates total resource requests for a pod
-func (pc *PodCollector) getPodRequests(pod *corev1.Pod) (int64, int64) {
-	var cpuRequest, memoryRequest int64
+		EphemeralStorageRequest: pc.getPodEphemeralStorageRequest(pod),
 
-	for _, container := range pod.Spec.Containers {
-		if cpu := container.Resources.Requests.Cpu(); cpu != nil {
-			cpuRequest += cpu.MilliValue()
-		}
-		if memory := container.Resources.Requests.Memory(); memory != nil {
-			memoryRequest += memory.Value()
-		}
+		ContainerResources: podContainerResources(pod),
+		CPUOverhead:        pod.Spec.Overhead.Cpu().MilliValue(),
+		MemoryOverhead:     pod.Spec.Overhead.Memory().Value(),
 	}
+}
 
-	// Include init containers (they run sequentially, so take max)
-	var maxInitCPU, maxInitMemory int64
-	for _, container := range pod.Spec.InitContainers {
-		if cpu := container.Resources.Requests.Cpu(); cpu != nil {
-			if cpu.MilliValue() > maxInitCPU {
-				maxInitCPU = cpu.MilliValue()
-			}
-		}
-		if memory := container.Resources.Requests.Memory(); memory != nil {
-			if memory.Value() > maxInitMemory {
-				maxInitMemory = memory.Value()
-			}
+// podClaimNames returns the names of the persistent volume claims a pod mounts,
+// including claims created for its generic ephemeral volumes
+func podClaimNames(pod *corev1.Pod) []string {
+	var claims []string
+	for _, volume := range pod.Spec.Volumes {
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			claims = append(claims, volume.PersistentVolumeClaim.ClaimName)
+		case volume.Ephemeral != nil:
+			claims = append(claims, pod.Name+"-"+volume.Name)
 		}
 	}
+	return claims
+}
 
-	if maxInitCPU > cpuRequest {
-		cpuRequest = maxInitCPU
-	}
-	if maxInitMemory > memoryRequest {
-		memoryRequest = maxInitMemory
+// requiredNodeAffinity returns the node affinity a pod requires to be scheduled
+func requiredNodeAffinity(pod *corev1.Pod) *corev1.NodeSelector {
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil {
+		return nil
 	}
-
-	return cpuRequest, memoryRequest
+	return pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
 }
 
-// getPodLimits calculates total resource limits for a pod
-func (pc *PodCollector) getPodLimits(pod *corev1.Pod) (int64, int64) {
-	var cpuLimit, memoryLimit int64
+// getPodRequests returns a pod's effective CPU (millicores) and memory (bytes)
+// requests, including init containers, native sidecars and pod overhead
+func (pc *PodCollector) getPodRequests(pod *corev1.Pod) (int64, int64) {
+	requests := podRequests(pod)
+	return requests.Cpu().MilliValue(), requests.Memory().Value()
+}
 
-	for _, container := range pod.Spec.Containers {
-		if cpu := container.Resources.Limits.Cpu(); cpu != nil {
-			cpuLimit += cpu.MilliValue()
-		}
-		if memory := container.Resources.Limits.Memory(); memory != nil {
-			memoryLimit += memory.Value()
-		}
-	}
+// getPodGPURequest returns the number of GPUs requested by a pod's containers
+func (pc *PodCollector) getPodGPURequest(pod *corev1.Pod) int64 {
+	return gpuCount(podRequests(pod))
+}
 
-	return cpuLimit, memoryLimit
+// getPodEphemeralStorageRequest returns the ephemeral storage requested by a pod's
+// containers
+func (pc *PodCollector) getPodEphemeralStorageRequest(pod *corev1.Pod) int64 {
+	requests := podRequests(pod)
+	return requests.StorageEphemeral().Value()
 }
 
-// getPodOwner extracts the owner reference (Deployment, StatefulSet, etc.)
-func (pc *PodCollector) getPodOwner(pod *corev1.Pod) (string, string) {
-	if len(pod.OwnerReferences) == 0 {
-		return "Pod", pod.Name
-	}
+// getPodLimits returns a pod's effective CPU (millicores) and memory (bytes)
+// limits, computed like its requests
+func (pc *PodCollector) getPodLimits(pod *corev1.Pod) (int64, int64) {
+	limits := podLimits(pod)
+	return limits.Cpu().MilliValue(), limits.Memory().Value()
+}
 
-	owner := pod.OwnerReferences[0]
-	ownerKind := owner.Kind
-	ownerName := owner.Name
-
-	// For ReplicaSets, try to find the Deployment owner
-	if ownerKind == "ReplicaSet" {
-		// The ReplicaSet name typically includes the Deployment name as a prefix
-		// Format: <deployment-name>-<hash>
-		// We'll just return the ReplicaSet for now; in production,
-		// you'd query the ReplicaSet to find its Deployment owner
-		return "ReplicaSet", ownerName
+// getPodOwner returns the top-level workload that owns a pod (Deployment,
+// CronJob, Rollout, ...), or the pod itself if it has no owner
+func (pc *PodCollector) getPodOwner(ctx context.Context, pod *corev1.Pod) (string, string) {
+	if kind, name, ok := pc.owners.Resolve(ctx, pod.Namespace, pod.OwnerReferences); ok {
+		return kind, name
 	}
-
-	return ownerKind, ownerName
+	return "Pod", pod.Name
 }
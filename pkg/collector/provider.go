@@ -0,0 +1,110 @@
+package collector
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// providerIDPrefixes maps Spec.ProviderID schemes to pricing provider names
+var providerIDPrefixes = map[string]string{
+	"aws://":          "aws",
+	"gce://":          "gcp",
+	"azure://":        "azure",
+	"oci://":          "oracle",
+	"ocid1.instance.": "oracle",
+	"digitalocean://": "digitalocean",
+	"linode://":       "linode",
+	"hcloud://":       "hetzner",
+}
+
+// instanceIDProviders are providers whose provider IDs end in an instance ID
+// rather than an instance type
+var instanceIDProviders = map[string]bool{
+	"aws":          true,
+	"oracle":       true,
+	"digitalocean": true,
+	"linode":       true,
+	"hetzner":      true,
+}
+
+// providerLabelPrefixes maps well-known node label prefixes to pricing provider
+// names. They are checked in order, so a node with labels of several providers
+// always resolves to the same one.
+var providerLabelPrefixes = []struct {
+	prefix   string
+	provider string
+}{
+	{"eks.amazonaws.com/", "aws"},
+	{"alpha.eksctl.io/", "aws"},
+	{"cloud.google.com/", "gcp"},
+	{"kubernetes.azure.com/", "azure"},
+	{"oci.oraclecloud.com/", "oracle"},
+	{"oke.oraclecloud.com/", "oracle"},
+	{"doks.digitalocean.com/", "digitalocean"},
+	{"lke.linode.com/", "linode"},
+	{"instance.hetzner.cloud/", "hetzner"},
+}
+
+// detectNodeProvider determines which cloud provider a node runs on from its
+// provider ID and labels. Returns an empty string if the provider is unknown.
+func detectNodeProvider(node *corev1.Node) string {
+	if provider := providerFromID(node.Spec.ProviderID); provider != "" {
+		return provider
+	}
+
+	for _, label := range providerLabelPrefixes {
+		for key := range node.Labels {
+			if strings.HasPrefix(key, label.prefix) {
+				return label.provider
+			}
+		}
+	}
+
+	return ""
+}
+
+// detectVolumeProvider determines which cloud provider backs a persistent volume
+// from its volume source or CSI driver. Returns an empty string if unknown.
+func detectVolumeProvider(pv *corev1.PersistentVolume) string {
+	switch {
+	case pv.Spec.AWSElasticBlockStore != nil:
+		return "aws"
+	case pv.Spec.GCEPersistentDisk != nil:
+		return "gcp"
+	case pv.Spec.AzureDisk != nil, pv.Spec.AzureFile != nil:
+		return "azure"
+	}
+
+	if pv.Spec.CSI != nil {
+		driver := pv.Spec.CSI.Driver
+		switch {
+		case strings.HasSuffix(driver, ".csi.aws.com"):
+			return "aws"
+		case strings.HasSuffix(driver, ".csi.storage.gke.io"):
+			return "gcp"
+		case strings.HasSuffix(driver, ".csi.azure.com"):
+			return "azure"
+		case strings.HasSuffix(driver, ".csi.oraclecloud.com"):
+			return "oracle"
+		case strings.HasSuffix(driver, ".csi.digitalocean.com"):
+			return "digitalocean"
+		case strings.HasSuffix(driver, ".csi.linode.com"):
+			return "linode"
+		case driver == "csi.hetzner.cloud":
+			return "hetzner"
+		}
+	}
+
+	return ""
+}
+
+// providerFromID maps a provider ID such as aws:///us-east-1a/i-0abc to a provider name
+func providerFromID(providerID string) string {
+	for prefix, provider := range providerIDPrefixes {
+		if strings.HasPrefix(providerID, prefix) {
+			return provider
+		}
+	}
+	return ""
+}
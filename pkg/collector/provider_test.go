@@ -0,0 +1,47 @@
+package collector
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDetectNodeProvider(t *testing.T) {
+	tests := []struct {
+		name       string
+		providerID string
+		labels     map[string]string
+		want       string
+	}{
+		{name: "provider ID", providerID: "aws:///us-east-1a/i-0abc", want: "aws"},
+		{name: "provider ID wins over labels", providerID: "hcloud://12345", labels: map[string]string{"cloud.google.com/gke-nodepool": "pool"}, want: "hetzner"},
+		{name: "label prefix", labels: map[string]string{"lke.linode.com/pool-id": "1"}, want: "linode"},
+		{
+			name: "labels of several providers resolve in prefix order",
+			labels: map[string]string{
+				"karpenter.sh/nodepool":          "default",
+				"kubernetes.azure.com/agentpool": "pool",
+				"eks.amazonaws.com/nodegroup":    "group",
+				"instance.hetzner.cloud/type":    "cx22",
+			},
+			want: "aws",
+		},
+		{name: "unknown", labels: map[string]string{"karpenter.sh/nodepool": "default"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node", Labels: tt.labels},
+				Spec:       corev1.NodeSpec{ProviderID: tt.providerID},
+			}
+			// Map iteration order varies, so repeat to catch nondeterminism
+			for i := 0; i < 20; i++ {
+				if got := detectNodeProvider(node); got != tt.want {
+					t.Fatalf("detectNodeProvider() = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
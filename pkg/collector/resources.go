@@ -0,0 +1,138 @@
+package collector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ContainerResources are the requests and limits of a container that runs for the
+// pod's lifetime: an app container or a native sidecar
+type ContainerResources struct {
+	Name          string
+	Sidecar       bool  // restartable init container
+	CPURequest    int64 // millicores
+	MemoryRequest int64 // bytes
+	GPURequest    int64
+	CPULimit      int64 // millicores
+	MemoryLimit   int64 // bytes
+}
+
+// podRequests returns a pod's effective resource requests as the scheduler
+// computes them, including its pod overhead
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	return effectivePodResources(pod, func(container corev1.Container) corev1.ResourceList {
+		return container.Resources.Requests
+	}, false)
+}
+
+// podLimits returns a pod's effective resource limits. Pod overhead is only added
+// to resources that are limited.
+func podLimits(pod *corev1.Pod) corev1.ResourceList {
+	return effectivePodResources(pod, func(container corev1.Container) corev1.ResourceList {
+		return container.Resources.Limits
+	}, true)
+}
+
+// effectivePodResources adds up a pod's container resources the way the scheduler
+// does. App containers and native sidecars run together for the pod's lifetime.
+// Every other init container runs alone, alongside the sidecars started before it,
+// and the pod needs the larger of its init and running resources. The overhead of
+// the pod's runtime class is added on top.
+func effectivePodResources(pod *corev1.Pod, resources func(corev1.Container) corev1.ResourceList, overheadOnlyIfSet bool) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResources(total, resources(container))
+	}
+
+	sidecars := corev1.ResourceList{}
+	initPeak := corev1.ResourceList{}
+	for _, container := range pod.Spec.InitContainers {
+		if isSidecar(container) {
+			addResources(total, resources(container))
+			addResources(sidecars, resources(container))
+			maxResources(initPeak, sidecars)
+			continue
+		}
+
+		running := corev1.ResourceList{}
+		addResources(running, resources(container))
+		addResources(running, sidecars)
+		maxResources(initPeak, running)
+	}
+	maxResources(total, initPeak)
+
+	for name, quantity := range pod.Spec.Overhead {
+		if _, ok := total[name]; overheadOnlyIfSet && !ok {
+			continue
+		}
+		addResources(total, corev1.ResourceList{name: quantity})
+	}
+
+	return total
+}
+
+// podContainerResources returns the requests and limits of a pod's app containers
+// and native sidecars
+func podContainerResources(pod *corev1.Pod) []ContainerResources {
+	var containers []ContainerResources
+	for _, container := range pod.Spec.InitContainers {
+		if isSidecar(container) {
+			containers = append(containers, containerResources(container, true))
+		}
+	}
+	for _, container := range pod.Spec.Containers {
+		containers = append(containers, containerResources(container, false))
+	}
+	return containers
+}
+
+// containerResources returns a container's requests and limits
+func containerResources(container corev1.Container, sidecar bool) ContainerResources {
+	requests, limits := container.Resources.Requests, container.Resources.Limits
+	return ContainerResources{
+		Name:          container.Name,
+		Sidecar:       sidecar,
+		CPURequest:    requests.Cpu().MilliValue(),
+		MemoryRequest: requests.Memory().Value(),
+		GPURequest:    gpuCount(requests),
+		CPULimit:      limits.Cpu().MilliValue(),
+		MemoryLimit:   limits.Memory().Value(),
+	}
+}
+
+// isSidecar reports whether an init container is a native sidecar, which keeps
+// running alongside the app containers
+func isSidecar(container corev1.Container) bool {
+	return container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways
+}
+
+// gpuCount returns the number of GPUs in a resource list
+func gpuCount(resources corev1.ResourceList) int64 {
+	var gpus int64
+	for _, resource := range gpuResources {
+		if quantity, ok := resources[resource]; ok {
+			gpus += quantity.Value()
+		}
+	}
+	return gpus
+}
+
+// addResources adds the quantities in add to total
+func addResources(total, add corev1.ResourceList) {
+	for name, quantity := range add {
+		if current, ok := total[name]; ok {
+			current.Add(quantity)
+			total[name] = current
+		} else {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// maxResources raises the quantities in total to those in other where larger
+func maxResources(total, other corev1.ResourceList) {
+	for name, quantity := range other {
+		if current, ok := total[name]; !ok || quantity.Cmp(current) > 0 {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package collector
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestPodRequests(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	container := func(name, cpu, memory string) corev1.Container {
+		return corev1.Container{
+			Name: name,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+			},
+		}
+	}
+	sidecar := func(name, cpu, memory string) corev1.Container {
+		c := container(name, cpu, memory)
+		c.RestartPolicy = &always
+		return c
+	}
+
+	tests := []struct {
+		name       string
+		spec       corev1.PodSpec
+		wantCPU    int64 // millicores
+		wantMemory string
+	}{
+		{
+			name:       "app containers",
+			spec:       corev1.PodSpec{Containers: []corev1.Container{container("app", "500m", "1Gi"), container("proxy", "100m", "128Mi")}},
+			wantCPU:    600,
+			wantMemory: "1152Mi",
+		},
+		{
+			name: "larger init container",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{container("migrate", "2", "512Mi")},
+				Containers:     []corev1.Container{container("app", "500m", "1Gi")},
+			},
+			wantCPU:    2000,
+			wantMemory: "1Gi",
+		},
+		{
+			name: "native sidecars run with app and later init containers",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					sidecar("istio-proxy", "100m", "128Mi"),
+					container("migrate", "1", "256Mi"),
+				},
+				Containers: []corev1.Container{container("app", "500m", "1Gi")},
+			},
+			wantCPU:    1100, // migrate with istio-proxy
+			wantMemory: "1152Mi",
+		},
+		{
+			name: "init containers before a sidecar run without it",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					container("setup", "550m", "64Mi"),
+					sidecar("vault-agent", "100m", "64Mi"),
+				},
+				Containers: []corev1.Container{container("app", "500m", "1Gi")},
+			},
+			wantCPU:    600,
+			wantMemory: "1088Mi",
+		},
+		{
+			name: "pod overhead",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{container("app", "500m", "1Gi")},
+				Overhead: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("250m"),
+					corev1.ResourceMemory: resource.MustParse("160Mi"),
+				},
+			},
+			wantCPU:    750,
+			wantMemory: "1184Mi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := podRequests(&corev1.Pod{Spec: tt.spec})
+			if got := requests.Cpu().MilliValue(); got != tt.wantCPU {
+				t.Errorf("CPU request = %dm, want %dm", got, tt.wantCPU)
+			}
+			if got, want := requests.Memory().Value(), resource.MustParse(tt.wantMemory); got != want.Value() {
+				t.Errorf("memory request = %d, want %s", got, tt.wantMemory)
+			}
+		})
+	}
+}
+
+func TestPodLimitsOnlyAddOverheadToLimitedResources(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name: "app",
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		}},
+		Overhead: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("250m"),
+			corev1.ResourceMemory: resource.MustParse("160Mi"),
+		},
+	}}
+
+	limits := podLimits(pod)
+	if _, ok := limits[corev1.ResourceCPU]; ok {
+		t.Errorf("CPU limit = %v, want unlimited", limits.Cpu())
+	}
+	if got, want := limits.Memory().Value(), resource.MustParse("1184Mi"); got != want.Value() {
+		t.Errorf("memory limit = %d, want 1184Mi", got)
+	}
+}
+
+func TestPodContainerResources(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			{Name: "setup"},
+			{Name: "istio-proxy", RestartPolicy: &always},
+		},
+		Containers: []corev1.Container{{Name: "app"}},
+	}}
+
+	containers := podContainerResources(pod)
+	if len(containers) != 2 {
+		t.Fatalf("got %d containers, want the sidecar and app container", len(containers))
+	}
+	if containers[0].Name != "istio-proxy" || !containers[0].Sidecar {
+		t.Errorf("first container = %+v, want the istio-proxy sidecar", containers[0])
+	}
+	if containers[1].Name != "app" || containers[1].Sidecar {
+		t.Errorf("second container = %+v, want the app container", containers[1])
+	}
+}
@@ -0,0 +1,102 @@
+package collector
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodEligibleForNode reports whether a pod's node selector, required node affinity
+// and tolerations allow it to be scheduled to a node. Resources are not checked.
+func PodEligibleForNode(pod PodInfo, node NodeInfo) bool {
+	for key, value := range pod.NodeSelector {
+		if node.Labels[key] != value {
+			return false
+		}
+	}
+
+	if pod.NodeAffinity != nil && !matchesNodeSelector(pod.NodeAffinity, node.Labels) {
+		return false
+	}
+
+	for _, taint := range node.Taints {
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		if !toleratesTaint(pod.Tolerations, taint) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesNodeSelector reports whether node labels match any of a node selector's
+// terms. Field selectors are not supported and never match.
+func matchesNodeSelector(selector *corev1.NodeSelector, nodeLabels map[string]string) bool {
+	for _, term := range selector.NodeSelectorTerms {
+		if len(term.MatchFields) > 0 || len(term.MatchExpressions) == 0 {
+			continue
+		}
+
+		matches := true
+		for _, requirement := range term.MatchExpressions {
+			if !matchesRequirement(requirement, nodeLabels) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesRequirement reports whether node labels satisfy a node selector requirement
+func matchesRequirement(requirement corev1.NodeSelectorRequirement, nodeLabels map[string]string) bool {
+	value, exists := nodeLabels[requirement.Key]
+
+	switch requirement.Operator {
+	case corev1.NodeSelectorOpIn, corev1.NodeSelectorOpNotIn:
+		found := false
+		for _, v := range requirement.Values {
+			if exists && v == value {
+				found = true
+				break
+			}
+		}
+		return found == (requirement.Operator == corev1.NodeSelectorOpIn)
+	case corev1.NodeSelectorOpExists:
+		return exists
+	case corev1.NodeSelectorOpDoesNotExist:
+		return !exists
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if !exists || len(requirement.Values) != 1 {
+			return false
+		}
+		labelValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+		bound, err := strconv.ParseInt(requirement.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+		if requirement.Operator == corev1.NodeSelectorOpGt {
+			return labelValue > bound
+		}
+		return labelValue < bound
+	}
+	return false
+}
+
+// toleratesTaint reports whether any of the tolerations tolerates a taint
+func toleratesTaint(tolerations []corev1.Toleration, taint corev1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(&taint) {
+			return true
+		}
+	}
+	return false
+}
@@ -3,77 +3,157 @@ package collector
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/deepcost/kube-cost-exporter/pkg/pricing"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
+// storageTypeParameters are StorageClass parameters that name the provider's
+// volume type (gp3, pd-ssd, Premium_LRS, ...)
+var storageTypeParameters = []string{
+	"type",
+	"skuName",
+	"skuname",
+	"storageaccounttype",
+}
+
 // StorageCollector collects persistent volume information and pricing
 type StorageCollector struct {
-	clientset     *kubernetes.Clientset
-	pricingCache  *pricing.PricingCache
-	cloudProvider string
-	region        string
-	logger        *logrus.Logger
+	informers *InformerCache
+	owners    *OwnerResolver
+	registry  *pricing.Registry
+	region    string
+	logger    *logrus.Logger
+
+	mu  sync.Mutex
+	pvs map[string]PVInfo // priced volumes by name, recalculated when they change
 }
 
-// NewStorageCollector creates a new storage collector
-func NewStorageCollector(clientset *kubernetes.Clientset, pricingCache *pricing.PricingCache, cloudProvider, region string) *StorageCollector {
+// NewStorageCollector creates a new storage collector. Volumes are priced with the
+// provider detected from their volume source, falling back to the registry's default.
+// StatefulSet volume claims are attributed to the top-level workload found by the
+// owner resolver.
+func NewStorageCollector(informers *InformerCache, owners *OwnerResolver, registry *pricing.Registry, region string) *StorageCollector {
 	logger := logrus.New()
 	logger.SetLevel(logrus.InfoLevel)
 
 	return &StorageCollector{
-		clientset:     clientset,
-		pricingCache:  pricingCache,
-		cloudProvider: cloudProvider,
-		region:        region,
-		logger:        logger,
+		informers: informers,
+		owners:    owners,
+		registry:  registry,
+		region:    region,
+		logger:    logger,
+		pvs:       make(map[string]PVInfo),
 	}
 }
 
 // PVInfo contains information about a persistent volume and its pricing
 type PVInfo struct {
-	Name         string
-	StorageClass string
-	Namespace    string
-	PVCName      string
-	SizeGB       int64
-	PricePerGB   float64
-	MonthlyCost  float64
-	Region       string
+	Name          string
+	CloudProvider string
+	StorageClass  string
+	StorageType   string // provider volume type used for pricing
+	Namespace     string
+	PVCName       string
+	SizeGB        int64
+	PricePerGB    float64
+	MonthlyCost   float64
+	Region        string
+
+	// Workload the claim belongs to when no pod mounts it, for StatefulSet
+	// volumeClaimTemplates
+	OwnerKind string
+	OwnerName string
+
+	// Volume lifecycle, used to detect volumes that are paid for but unused
+	Phase         corev1.PersistentVolumePhase
+	ReclaimPolicy corev1.PersistentVolumeReclaimPolicy
+	PhaseTime     time.Time // when the volume entered its phase, or was created
 }
 
-// CollectPVs collects all persistent volumes and their pricing
+// CollectPVs collects all persistent volumes and their pricing. Only volumes that
+// changed since the last collection are repriced; a StorageClass change reprices
+// every volume.
 func (sc *StorageCollector) CollectPVs(ctx context.Context) ([]PVInfo, error) {
-	pvs, err := sc.clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list persistent volumes: %w", err)
-	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
 
-	var pvInfos []PVInfo
-	for _, pv := range pvs.Items {
-		pvInfo, err := sc.collectPVInfo(ctx, &pv)
+	changes := sc.informers.Changes()
+	pvChanges, full := changes.TakeChanges(KindPersistentVolume)
+	classChanges, classesFull := changes.TakeChanges(KindStorageClass)
+	// PVC changes do not affect volume pricing; the claim is read from the PV
+	changes.TakeChanges(KindPersistentVolumeClaim)
+
+	if full || classesFull || len(classChanges) > 0 {
+		pvs, err := sc.informers.PersistentVolumes.List(labels.Everything())
 		if err != nil {
-			sc.logger.Warnf("Failed to collect info for PV %s: %v", pv.Name, err)
-			continue
+			return nil, fmt.Errorf("failed to list persistent volumes: %w", err)
+		}
+
+		sc.pvs = make(map[string]PVInfo, len(pvs))
+		for _, pv := range pvs {
+			sc.updatePV(ctx, pv)
 		}
+	} else {
+		for name, deleted := range pvChanges {
+			if deleted {
+				delete(sc.pvs, name)
+				continue
+			}
+			pv, err := sc.informers.PersistentVolumes.Get(name)
+			if err != nil {
+				delete(sc.pvs, name)
+				continue
+			}
+			sc.updatePV(ctx, pv)
+		}
+	}
+
+	pvInfos := make([]PVInfo, 0, len(sc.pvs))
+	for _, pvInfo := range sc.pvs {
 		pvInfos = append(pvInfos, pvInfo)
 	}
+	sort.Slice(pvInfos, func(i, j int) bool {
+		return pvInfos[i].Name < pvInfos[j].Name
+	})
 
 	return pvInfos, nil
 }
 
+// updatePV reprices a persistent volume and stores it in the volume cache
+func (sc *StorageCollector) updatePV(ctx context.Context, pv *corev1.PersistentVolume) {
+	pvInfo, err := sc.collectPVInfo(ctx, pv)
+	if err != nil {
+		sc.logger.Warnf("Failed to collect info for PV %s: %v", pv.Name, err)
+		delete(sc.pvs, pv.Name)
+		return
+	}
+	sc.pvs[pv.Name] = pvInfo
+}
+
 // collectPVInfo extracts pricing information for a single persistent volume
 func (sc *StorageCollector) collectPVInfo(ctx context.Context, pv *corev1.PersistentVolume) (PVInfo, error) {
-	storageClass := sc.getStorageClass(pv)
+	provider, pricingCache := sc.registry.Resolve(detectVolumeProvider(pv))
+	if pricingCache == nil {
+		return PVInfo{}, fmt.Errorf("no pricing provider registered for %q", provider)
+	}
+
+	storageClass := sc.getStorageClass(pv, provider)
+	storageType := sc.getStorageType(storageClass)
 	sizeGB := sc.getPVSizeGB(pv)
 	namespace, pvcName := sc.getPVCInfo(pv)
+	ownerKind, ownerName := sc.getClaimOwner(ctx, namespace, pvcName)
+	region := sc.getRegion(pv)
 
 	// Get storage pricing
-	pricePerGB, err := sc.pricingCache.GetStoragePrice(ctx, storageClass, sc.region)
+	pricePerGB, err := pricingCache.GetStoragePrice(ctx, storageType, region)
 	if err != nil {
 		sc.logger.Warnf("Failed to get storage price for %s: %v", pv.Name, err)
 		pricePerGB = 0.10 // Default fallback
@@ -82,19 +162,26 @@ func (sc *StorageCollector) collectPVInfo(ctx context.Context, pv *corev1.Persis
 	monthlyCost := float64(sizeGB) * pricePerGB
 
 	return PVInfo{
-		Name:         pv.Name,
-		StorageClass: storageClass,
-		Namespace:    namespace,
-		PVCName:      pvcName,
-		SizeGB:       sizeGB,
-		PricePerGB:   pricePerGB,
-		MonthlyCost:  monthlyCost,
-		Region:       sc.region,
+		Name:          pv.Name,
+		CloudProvider: provider,
+		StorageClass:  storageClass,
+		StorageType:   storageType,
+		Namespace:     namespace,
+		PVCName:       pvcName,
+		SizeGB:        sizeGB,
+		PricePerGB:    pricePerGB,
+		MonthlyCost:   monthlyCost,
+		Region:        region,
+		OwnerKind:     ownerKind,
+		OwnerName:     ownerName,
+		Phase:         pv.Status.Phase,
+		ReclaimPolicy: pv.Spec.PersistentVolumeReclaimPolicy,
+		PhaseTime:     getPhaseTime(pv),
 	}, nil
 }
 
 // getStorageClass extracts the storage class from PV
-func (sc *StorageCollector) getStorageClass(pv *corev1.PersistentVolume) string {
+func (sc *StorageCollector) getStorageClass(pv *corev1.PersistentVolume, provider string) string {
 	if pv.Spec.StorageClassName != "" {
 		return pv.Spec.StorageClassName
 	}
@@ -105,7 +192,7 @@ func (sc *StorageCollector) getStorageClass(pv *corev1.PersistentVolume) string
 	}
 
 	// Map cloud-specific storage types
-	switch sc.cloudProvider {
+	switch provider {
 	case "aws":
 		if pv.Spec.AWSElasticBlockStore != nil {
 			return "gp2" // Default EBS type
@@ -121,11 +208,52 @@ func (sc *StorageCollector) getStorageClass(pv *corev1.PersistentVolume) string
 			return "StandardSSD_LRS"
 		}
 		return "Standard_LRS"
+	case "oracle":
+		return "oci-bv"
+	case "digitalocean":
+		return "do-block-storage"
+	case "linode":
+		return "linode-block-storage"
+	case "hetzner":
+		return "hcloud-volumes"
 	}
 
 	return "standard"
 }
 
+// getStorageType returns the provider volume type from the StorageClass parameters,
+// falling back to the class name (e.g. gp3 or pd-ssd classes named after their type)
+func (sc *StorageCollector) getStorageType(storageClass string) string {
+	class, err := sc.informers.StorageClasses.Get(storageClass)
+	if err != nil {
+		return storageClass
+	}
+
+	for _, parameter := range storageTypeParameters {
+		if storageType, ok := class.Parameters[parameter]; ok && storageType != "" {
+			return storageType
+		}
+	}
+
+	return storageClass
+}
+
+// getRegion extracts the region from PV topology labels
+func (sc *StorageCollector) getRegion(pv *corev1.PersistentVolume) string {
+	labelKeys := []string{
+		"topology.kubernetes.io/region",
+		"failure-domain.beta.kubernetes.io/region",
+	}
+
+	for _, key := range labelKeys {
+		if region, ok := pv.Labels[key]; ok {
+			return region
+		}
+	}
+
+	return sc.region // Use configured region as fallback
+}
+
 // getPVSizeGB extracts the size in GB from PV
 func (sc *StorageCollector) getPVSizeGB(pv *corev1.PersistentVolume) int64 {
 	if capacity, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok {
@@ -148,21 +276,63 @@ func (sc *StorageCollector) getPVCInfo(pv *corev1.PersistentVolume) (string, str
 	return "", ""
 }
 
+// getPhaseTime returns when a volume entered its current phase, falling back to
+// its creation time on clusters that do not record phase transitions
+func getPhaseTime(pv *corev1.PersistentVolume) time.Time {
+	if pv.Status.LastPhaseTransitionTime != nil {
+		return pv.Status.LastPhaseTransitionTime.Time
+	}
+	return pv.CreationTimestamp.Time
+}
+
+// getClaimOwner returns the top-level workload of the StatefulSet a claim was
+// created for from its volumeClaimTemplates, matching claims named
+// <template>-<statefulset>-<ordinal>
+func (sc *StorageCollector) getClaimOwner(ctx context.Context, namespace, claimName string) (string, string) {
+	if claimName == "" {
+		return "", ""
+	}
+
+	statefulSets, err := sc.informers.StatefulSets.StatefulSets(namespace).List(labels.Everything())
+	if err != nil {
+		return "", ""
+	}
+
+	for _, statefulSet := range statefulSets {
+		for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+			ordinal, ok := strings.CutPrefix(claimName, template.Name+"-"+statefulSet.Name+"-")
+			if !ok {
+				continue
+			}
+			if _, err := strconv.Atoi(ordinal); err != nil {
+				continue
+			}
+
+			if kind, name, ok := sc.owners.Resolve(ctx, namespace, statefulSet.OwnerReferences); ok {
+				return kind, name
+			}
+			return "StatefulSet", statefulSet.Name
+		}
+	}
+
+	return "", ""
+}
+
 // CollectPVCsInNamespace collects PVCs in a specific namespace
 func (sc *StorageCollector) CollectPVCsInNamespace(ctx context.Context, namespace string) ([]PVInfo, error) {
-	pvcs, err := sc.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	pvcs, err := sc.informers.PersistentVolumeClaims.PersistentVolumeClaims(namespace).List(labels.Everything())
 	if err != nil {
 		return nil, fmt.Errorf("failed to list PVCs in namespace %s: %w", namespace, err)
 	}
 
 	var pvInfos []PVInfo
-	for _, pvc := range pvcs.Items {
+	for _, pvc := range pvcs {
 		// Get the bound PV
 		if pvc.Spec.VolumeName == "" {
 			continue // Skip unbound PVCs
 		}
 
-		pv, err := sc.clientset.CoreV1().PersistentVolumes().Get(ctx, pvc.Spec.VolumeName, metav1.GetOptions{})
+		pv, err := sc.informers.PersistentVolumes.Get(pvc.Spec.VolumeName)
 		if err != nil {
 			sc.logger.Warnf("Failed to get PV %s for PVC %s/%s: %v", pvc.Spec.VolumeName, namespace, pvc.Name, err)
 			continue
@@ -0,0 +1,159 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+)
+
+// podMetricsPath is the metrics.k8s.io endpoint for pod usage
+const podMetricsPath = "/apis/metrics.k8s.io/v1beta1/pods"
+
+// UsageCollector collects pod CPU and memory usage from the metrics API and keeps a
+// rolling window of samples per pod
+type UsageCollector struct {
+	clientset kubernetes.Interface
+	window    time.Duration
+	logger    *logrus.Logger
+
+	mu      sync.Mutex
+	samples map[string][]usageSample // namespace/name -> samples, oldest first
+}
+
+// usageSample is a single pod usage measurement
+type usageSample struct {
+	timestamp   time.Time
+	cpuUsage    int64 // millicores
+	memoryUsage int64 // bytes
+}
+
+// podMetricsList is the metrics.k8s.io PodMetricsList format
+type podMetricsList struct {
+	Items []podMetrics `json:"items"`
+}
+
+type podMetrics struct {
+	Metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	Timestamp  time.Time          `json:"timestamp"`
+	Containers []containerMetrics `json:"containers"`
+}
+
+type containerMetrics struct {
+	Name  string                       `json:"name"`
+	Usage map[string]resource.Quantity `json:"usage"`
+}
+
+// NewUsageCollector creates a new usage collector that averages usage over window
+func NewUsageCollector(clientset kubernetes.Interface, window time.Duration) *UsageCollector {
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+
+	return &UsageCollector{
+		clientset: clientset,
+		window:    window,
+		logger:    logger,
+		samples:   make(map[string][]usageSample),
+	}
+}
+
+// CollectUsage reads current pod usage from the metrics API and adds it to the window
+func (uc *UsageCollector) CollectUsage(ctx context.Context) error {
+	data, err := uc.clientset.Discovery().RESTClient().Get().AbsPath(podMetricsPath).DoRaw(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get pod metrics: %w", err)
+	}
+
+	var metricsList podMetricsList
+	if err := json.Unmarshal(data, &metricsList); err != nil {
+		return fmt.Errorf("failed to parse pod metrics: %w", err)
+	}
+
+	for _, item := range metricsList.Items {
+		var cpuUsage, memoryUsage int64
+		for _, container := range item.Containers {
+			if cpu, ok := container.Usage["cpu"]; ok {
+				cpuUsage += cpu.MilliValue()
+			}
+			if memory, ok := container.Usage["memory"]; ok {
+				memoryUsage += memory.Value()
+			}
+		}
+		uc.RecordUsage(item.Metadata.Namespace, item.Metadata.Name, item.Timestamp, cpuUsage, memoryUsage)
+	}
+
+	uc.logger.Debugf("Collected usage for %d pods", len(metricsList.Items))
+	return nil
+}
+
+// RecordUsage adds a usage sample for a pod, so other sources such as the kubelet
+// summary API can feed the usage window
+func (uc *UsageCollector) RecordUsage(namespace, name string, timestamp time.Time, cpuUsage, memoryUsage int64) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	key := namespace + "/" + name
+	samples := uc.samples[key]
+	// Usage sources only refresh periodically, so skip repeated samples
+	if n := len(samples); n > 0 && !timestamp.After(samples[n-1].timestamp) {
+		return
+	}
+	uc.samples[key] = append(samples, usageSample{
+		timestamp:   timestamp,
+		cpuUsage:    cpuUsage,
+		memoryUsage: memoryUsage,
+	})
+}
+
+// trim drops samples that fell out of the window, and pods with none left
+func (uc *UsageCollector) trim() {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	cutoff := time.Now().Add(-uc.window)
+	for key, samples := range uc.samples {
+		i := 0
+		for i < len(samples) && samples[i].timestamp.Before(cutoff) {
+			i++
+		}
+		if i == len(samples) {
+			delete(uc.samples, key)
+		} else if i > 0 {
+			uc.samples[key] = append([]usageSample(nil), samples[i:]...)
+		}
+	}
+}
+
+// ApplyUsage sets the average CPU and memory usage over the window on each pod
+// that has usage samples
+func (uc *UsageCollector) ApplyUsage(pods []PodInfo) {
+	uc.trim()
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	for i := range pods {
+		samples := uc.samples[pods[i].Namespace+"/"+pods[i].Name]
+		if len(samples) == 0 {
+			continue
+		}
+
+		var cpuTotal, memoryTotal int64
+		for _, sample := range samples {
+			cpuTotal += sample.cpuUsage
+			memoryTotal += sample.memoryUsage
+		}
+
+		pods[i].HasUsage = true
+		pods[i].CPUUsage = cpuTotal / int64(len(samples))
+		pods[i].MemoryUsage = memoryTotal / int64(len(samples))
+	}
+}
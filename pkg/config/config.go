@@ -0,0 +1,218 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds settings loaded from the agent's YAML configuration file
+type Config struct {
+	GCP         GCPConfig        `yaml:"gcp"`
+	Allocation  AllocationConfig `yaml:"allocation"`
+	SharedCosts []SharedCostRule `yaml:"sharedCosts"`
+	Dimensions  []Dimension      `yaml:"dimensions"`
+	RootVolumes []RootVolume     `yaml:"rootVolumes"`
+	Sidecars    []SidecarRule    `yaml:"sidecars"`
+}
+
+// AllocationConfig selects how node costs are split between pods
+type AllocationConfig struct {
+	// Strategy is the default allocation strategy (unit-rate when empty)
+	Strategy string `yaml:"strategy"`
+
+	// Namespaces overrides the strategy for individual namespaces
+	Namespaces map[string]string `yaml:"namespaces"`
+
+	// Weights are the CPU and memory weights of the weighted strategy
+	Weights AllocationWeights `yaml:"weights"`
+}
+
+// AllocationWeights are the relative weights of CPU and memory in a node's cost
+type AllocationWeights struct {
+	CPU    float64 `yaml:"cpu"`
+	Memory float64 `yaml:"memory"`
+}
+
+// SharedCostRule redistributes the cost of shared namespaces or pods, such as
+// platform and monitoring components, to the namespaces that use them
+type SharedCostRule struct {
+	Name string `yaml:"name"`
+
+	// Source selects the namespaces and pods whose cost is shared
+	Source SharedCostSource `yaml:"source"`
+
+	// Targets are the namespaces the cost is distributed to. When empty, the cost
+	// is distributed to every namespace that is not the source of a rule.
+	Targets []string `yaml:"targets"`
+
+	// Distribution is even, proportional (to target cost, the default) or weighted
+	Distribution string `yaml:"distribution"`
+
+	// Weights are the target namespace weights of the weighted distribution
+	Weights map[string]float64 `yaml:"weights"`
+}
+
+// SharedCostSource selects shared costs by namespace and by pod labels
+type SharedCostSource struct {
+	Namespaces []string          `yaml:"namespaces"`
+	Labels     map[string]string `yaml:"labels"` // pods in any namespace with all these labels
+}
+
+// Dimension aggregates pod costs by the value of a label or annotation, such as a
+// team or cost center
+type Dimension struct {
+	Name string `yaml:"name"`
+
+	// Sources are tried in order and the first one set on the pod or its namespace
+	// is the pod's value. Pods without any are aggregated as unallocated.
+	Sources []DimensionSource `yaml:"sources"`
+}
+
+// DimensionSource is a pod label, namespace label or namespace annotation. Exactly
+// one must be set.
+type DimensionSource struct {
+	PodLabel            string `yaml:"podLabel"`
+	NamespaceLabel      string `yaml:"namespaceLabel"`
+	NamespaceAnnotation string `yaml:"namespaceAnnotation"`
+}
+
+// RootVolume sets the type and size of node root volumes, by default or for a node
+// pool. Unset fields fall back to the provider's default root volume type and the
+// size of the node's ephemeral storage.
+type RootVolume struct {
+	NodePool string  `yaml:"nodePool"` // empty for the default of all node pools
+	Type     string  `yaml:"type"`     // provider volume type, e.g. gp3
+	SizeGB   float64 `yaml:"sizeGB"`
+}
+
+// SidecarRule carves the cost of sidecar containers, such as service mesh proxies
+// and agents, out of each pod's cost and charges it to the team that owns them
+type SidecarRule struct {
+	Owner      string   `yaml:"owner"`      // team that owns the sidecar
+	Containers []string `yaml:"containers"` // container names, e.g. istio-proxy
+
+	// Namespace the cost is charged to (the __sidecar__ namespace when empty)
+	Namespace string `yaml:"namespace"`
+}
+
+// GCPConfig holds GCP discount settings
+type GCPConfig struct {
+	// DisableSustainedUseDiscounts turns off sustained-use discount modelling
+	DisableSustainedUseDiscounts bool `yaml:"disableSustainedUseDiscounts"`
+
+	// Commitments lists resource-based committed use discounts held in the project
+	Commitments []GCPCommitment `yaml:"commitments"`
+}
+
+// GCPCommitment is a resource-based committed use discount for a region and machine family
+type GCPCommitment struct {
+	Region   string  `yaml:"region"`
+	Family   string  `yaml:"family"` // machine family, e.g. n2
+	VCPUs    float64 `yaml:"vcpus"`
+	MemoryGB float64 `yaml:"memoryGB"`
+	Term     string  `yaml:"term"` // 1y or 3y
+}
+
+// Load reads the configuration file at path. An empty path returns the default configuration.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	for _, commitment := range cfg.GCP.Commitments {
+		if commitment.Term != "1y" && commitment.Term != "3y" {
+			return nil, fmt.Errorf("invalid term %q for GCP commitment %s/%s (use 1y or 3y)",
+				commitment.Term, commitment.Region, commitment.Family)
+		}
+	}
+
+	if cfg.Allocation.Weights.CPU < 0 || cfg.Allocation.Weights.Memory < 0 {
+		return nil, fmt.Errorf("allocation weights must not be negative")
+	}
+
+	names := make(map[string]bool)
+	for _, rule := range cfg.SharedCosts {
+		if rule.Name == "" || names[rule.Name] {
+			return nil, fmt.Errorf("shared cost rules must have unique names")
+		}
+		names[rule.Name] = true
+
+		if len(rule.Source.Namespaces) == 0 && len(rule.Source.Labels) == 0 {
+			return nil, fmt.Errorf("shared cost rule %s has no source namespaces or labels", rule.Name)
+		}
+		switch rule.Distribution {
+		case "", "even", "proportional", "weighted":
+		default:
+			return nil, fmt.Errorf("invalid distribution %q for shared cost rule %s (use even, proportional or weighted)",
+				rule.Distribution, rule.Name)
+		}
+		for namespace, weight := range rule.Weights {
+			if weight < 0 {
+				return nil, fmt.Errorf("weight of %s in shared cost rule %s must not be negative", namespace, rule.Name)
+			}
+		}
+	}
+
+	dimensions := make(map[string]bool)
+	for _, dimension := range cfg.Dimensions {
+		if dimension.Name == "" || dimensions[dimension.Name] {
+			return nil, fmt.Errorf("dimensions must have unique names")
+		}
+		dimensions[dimension.Name] = true
+
+		if len(dimension.Sources) == 0 {
+			return nil, fmt.Errorf("dimension %s has no sources", dimension.Name)
+		}
+		for _, source := range dimension.Sources {
+			set := 0
+			for _, key := range []string{source.PodLabel, source.NamespaceLabel, source.NamespaceAnnotation} {
+				if key != "" {
+					set++
+				}
+			}
+			if set != 1 {
+				return nil, fmt.Errorf("each source of dimension %s must set one of podLabel, namespaceLabel or namespaceAnnotation",
+					dimension.Name)
+			}
+		}
+	}
+
+	pools := make(map[string]bool)
+	for _, volume := range cfg.RootVolumes {
+		if pools[volume.NodePool] {
+			return nil, fmt.Errorf("root volumes must have unique node pools")
+		}
+		pools[volume.NodePool] = true
+
+		if volume.SizeGB < 0 {
+			return nil, fmt.Errorf("root volume size of node pool %q must not be negative", volume.NodePool)
+		}
+	}
+
+	sidecars := make(map[string]bool)
+	for _, rule := range cfg.Sidecars {
+		if rule.Owner == "" || len(rule.Containers) == 0 {
+			return nil, fmt.Errorf("sidecar rules must have an owner and containers")
+		}
+		for _, container := range rule.Containers {
+			if sidecars[container] {
+				return nil, fmt.Errorf("sidecar container %s is in more than one rule", container)
+			}
+			sidecars[container] = true
+		}
+	}
+
+	return cfg, nil
+}
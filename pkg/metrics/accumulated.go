@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/deepcost/kube-cost-exporter/pkg/calculator"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// accumulatedCostCollector exports total cost counters from the latest snapshot of
+// the cost accumulator. The counters are built on each scrape, so a scrape always
+// sees a complete set of series.
+type accumulatedCostCollector struct {
+	podDesc       *prometheus.Desc
+	namespaceDesc *prometheus.Desc
+	nodeDesc      *prometheus.Desc
+
+	mu         sync.RWMutex
+	pods       []calculator.AccumulatedCost
+	namespaces []calculator.AccumulatedCost
+	nodes      []calculator.AccumulatedCost
+}
+
+// newAccumulatedCostCollector creates a collector with no accumulated costs
+func newAccumulatedCostCollector() *accumulatedCostCollector {
+	return &accumulatedCostCollector{
+		podDesc: prometheus.NewDesc(
+			"kube_cost_pod_cost_usd_total",
+			"Total cost of pod in USD since the agent started tracking it",
+			[]string{"namespace", "pod", "node"}, nil,
+		),
+		namespaceDesc: prometheus.NewDesc(
+			"kube_cost_namespace_cost_usd_total",
+			"Total cost of namespace in USD since the agent started tracking it",
+			[]string{"namespace"}, nil,
+		),
+		nodeDesc: prometheus.NewDesc(
+			"kube_cost_node_cost_usd_total",
+			"Total cost of node in USD since the agent started tracking it",
+			[]string{"node"}, nil,
+		),
+	}
+}
+
+// update replaces the accumulated costs exported on the next scrape
+func (c *accumulatedCostCollector) update(pods, namespaces, nodes []calculator.AccumulatedCost) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pods, c.namespaces, c.nodes = pods, namespaces, nodes
+}
+
+// Describe implements prometheus.Collector
+func (c *accumulatedCostCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.podDesc
+	ch <- c.namespaceDesc
+	ch <- c.nodeDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *accumulatedCostCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, pod := range c.pods {
+		ch <- prometheus.MustNewConstMetric(c.podDesc, prometheus.CounterValue, pod.TotalCost, pod.Namespace, pod.Pod, pod.Node)
+	}
+	for _, ns := range c.namespaces {
+		ch <- prometheus.MustNewConstMetric(c.namespaceDesc, prometheus.CounterValue, ns.TotalCost, ns.Namespace)
+	}
+	for _, node := range c.nodes {
+		ch <- prometheus.MustNewConstMetric(c.nodeDesc, prometheus.CounterValue, node.TotalCost, node.Node)
+	}
+}
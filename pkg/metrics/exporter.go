@@ -10,8 +10,20 @@ import (
 // Exporter exports cost metrics for Prometheus
 type Exporter struct {
 	podHourlyCost       *prometheus.GaugeVec
+	podCPUUsage         *prometheus.GaugeVec
+	podMemoryUsage      *prometheus.GaugeVec
+	podCPUAllocated     *prometheus.GaugeVec
+	podMemoryAllocated  *prometheus.GaugeVec
+	podResourceCost     *prometheus.GaugeVec
+	podStrategy         *prometheus.GaugeVec
+	podNetworkCost      *prometheus.GaugeVec
+	podEphemeralCost    *prometheus.GaugeVec
+	containerHourlyCost *prometheus.GaugeVec
+	containerCPUUsage   *prometheus.GaugeVec
+	containerMemUsage   *prometheus.GaugeVec
 	namespaceHourlyCost *prometheus.GaugeVec
 	namespaceDailyCost  *prometheus.GaugeVec
+	workloadHourlyCost  *prometheus.GaugeVec
 	nodeHourlyCost          *prometheus.GaugeVec
 	spotSavings             prometheus.Gauge
 	clusterHourlyCost       prometheus.Gauge
@@ -22,6 +34,44 @@ type Exporter struct {
 	onDemandCostHourly      prometheus.Gauge
 	namespaceSpotUsage      *prometheus.GaugeVec
 	namespaceSpotPercentage *prometheus.GaugeVec
+	nodeListHourlyCost      *prometheus.GaugeVec
+	nodeDiscountSavings     *prometheus.GaugeVec
+	discountSavings         *prometheus.GaugeVec
+	commitmentCoverage      *prometheus.GaugeVec
+	nodePricingSource       *prometheus.GaugeVec
+	nodeArm64Equivalent     *prometheus.GaugeVec
+	workloadArm64Cost       *prometheus.GaugeVec
+	workloadArm64Savings    *prometheus.GaugeVec
+	nodeIdleCost            *prometheus.GaugeVec
+	nodePoolIdleCost        *prometheus.GaugeVec
+	clusterIdleCost         *prometheus.GaugeVec
+	nodeUnitRate            *prometheus.GaugeVec
+	accumulatedCosts        *accumulatedCostCollector
+	pendingPodCost          *prometheus.GaugeVec
+	pendingPodDuration      *prometheus.GaugeVec
+	namespacePendingCost    *prometheus.GaugeVec
+	namespacePendingPods    *prometheus.GaugeVec
+	namespaceDirectCost     *prometheus.GaugeVec
+	namespaceSharedCost     *prometheus.GaugeVec
+	dimensionHourlyCost     *prometheus.GaugeVec
+	daemonSetFleetCost      *prometheus.GaugeVec
+	nodePoolHourlyCost      *prometheus.GaugeVec
+	nodePoolNodes           *prometheus.GaugeVec
+	nodePoolCapacity        *prometheus.GaugeVec
+	nodePoolAllocated       *prometheus.GaugeVec
+	nodePoolSpotShare       *prometheus.GaugeVec
+	podStorageCost          *prometheus.GaugeVec
+	podTotalCost            *prometheus.GaugeVec
+	workloadStorageCost     *prometheus.GaugeVec
+	workloadTotalCost       *prometheus.GaugeVec
+	podEphemeralAllocated   *prometheus.GaugeVec
+	nodeRootVolumeCost      *prometheus.GaugeVec
+	nodeRootVolumeSize      *prometheus.GaugeVec
+	containerCPURequest     *prometheus.GaugeVec
+	containerMemoryRequest  *prometheus.GaugeVec
+	sidecarHourlyCost       *prometheus.GaugeVec
+	sidecarPods             *prometheus.GaugeVec
+	clusterSidecarCost      *prometheus.GaugeVec
 	logger                  *logrus.Logger
 }
 
@@ -38,6 +88,83 @@ func NewExporter() *Exporter {
 			},
 			[]string{"namespace", "pod", "node"},
 		),
+		podCPUUsage: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pod_cpu_usage_cores",
+				Help: "Average pod CPU usage over the usage window in cores",
+			},
+			[]string{"namespace", "pod", "node"},
+		),
+		podMemoryUsage: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pod_memory_usage_bytes",
+				Help: "Average pod memory usage over the usage window in bytes",
+			},
+			[]string{"namespace", "pod", "node"},
+		),
+		podCPUAllocated: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pod_cpu_allocated_cores",
+				Help: "Pod CPU the node cost is allocated by, per the allocation mode, in cores",
+			},
+			[]string{"namespace", "pod", "node"},
+		),
+		podMemoryAllocated: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pod_memory_allocated_bytes",
+				Help: "Pod memory the node cost is allocated by, per the allocation mode, in bytes",
+			},
+			[]string{"namespace", "pod", "node"},
+		),
+		podResourceCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pod_resource_hourly_usd",
+				Help: "Hourly cost of pod CPU, memory and GPUs at the node's unit rates in USD",
+			},
+			[]string{"namespace", "pod", "node", "resource"},
+		),
+		podStrategy: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pod_allocation_strategy",
+				Help: "Allocation strategy the pod cost was calculated with (always 1)",
+			},
+			[]string{"namespace", "pod", "strategy"},
+		),
+		podNetworkCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pod_network_hourly_usd",
+				Help: "Hourly network egress cost of pod in USD, priced as internet egress; an upper bound not included in the pod cost",
+			},
+			[]string{"namespace", "pod", "node"},
+		),
+		podEphemeralCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pod_ephemeral_storage_hourly_usd",
+				Help: "Hourly cost of pod ephemeral storage on the node root volume in USD",
+			},
+			[]string{"namespace", "pod", "node"},
+		),
+		containerHourlyCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_container_hourly_usd",
+				Help: "Hourly compute cost of container in USD, split by usage within its pod, or by requests without kubelet stats",
+			},
+			[]string{"namespace", "pod", "container"},
+		),
+		containerCPUUsage: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_container_cpu_usage_cores",
+				Help: "Container CPU usage from the kubelet summary in cores",
+			},
+			[]string{"namespace", "pod", "container"},
+		),
+		containerMemUsage: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_container_memory_usage_bytes",
+				Help: "Container memory working set from the kubelet summary in bytes",
+			},
+			[]string{"namespace", "pod", "container"},
+		),
 		namespaceHourlyCost: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "kube_cost_namespace_hourly_usd",
@@ -52,12 +179,19 @@ func NewExporter() *Exporter {
 			},
 			[]string{"namespace"},
 		),
+		workloadHourlyCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_workload_hourly_usd",
+				Help: "Hourly cost per workload in USD",
+			},
+			[]string{"namespace", "kind", "name"},
+		),
 		nodeHourlyCost: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "kube_cost_node_hourly_usd",
-				Help: "Hourly cost per node in USD",
+				Help: "Hourly cost per node in USD, including its root volume",
 			},
-			[]string{"node", "instance_type", "is_spot"},
+			[]string{"node", "provider", "instance_type", "arch", "is_spot"},
 		),
 		spotSavings: prometheus.NewGauge(
 			prometheus.GaugeOpts{
@@ -115,6 +249,266 @@ func NewExporter() *Exporter {
 			},
 			[]string{"namespace"},
 		),
+		nodeListHourlyCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_node_list_hourly_usd",
+				Help: "Hourly list price of node in USD before discounts",
+			},
+			[]string{"node"},
+		),
+		nodeDiscountSavings: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_node_discount_savings_hourly_usd",
+				Help: "Hourly savings per node from each discount type in USD",
+			},
+			[]string{"node", "discount_type"},
+		),
+		discountSavings: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_discount_savings_hourly_usd",
+				Help: "Hourly savings from each discount type in USD",
+			},
+			[]string{"discount_type"},
+		),
+		commitmentCoverage: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_commitment_coverage_ratio",
+				Help: "Fraction of on-demand usage covered by committed use discounts",
+			},
+			[]string{"region", "family", "resource"},
+		),
+		nodePricingSource: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_node_pricing_source",
+				Help: "How the node price was determined (list, flexible, inferred, component)",
+			},
+			[]string{"node", "source", "instance_type"},
+		),
+		nodeArm64Equivalent: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_node_arm64_equivalent_hourly_usd",
+				Help: "Hourly price of the closest arm64 instance type to an amd64 node in USD",
+			},
+			[]string{"node", "instance_type", "arm64_instance_type"},
+		),
+		workloadArm64Cost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_workload_arm64_equivalent_hourly_usd",
+				Help: "Estimated hourly cost of a workload's amd64 pods on arm64 nodes in USD",
+			},
+			[]string{"namespace", "owner_kind", "owner_name"},
+		),
+		workloadArm64Savings: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_workload_arm64_savings_hourly_usd",
+				Help: "Estimated hourly savings from moving a workload's amd64 pods to arm64 in USD",
+			},
+			[]string{"namespace", "owner_kind", "owner_name"},
+		),
+		nodeIdleCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_node_idle_hourly_usd",
+				Help: "Hourly cost of node capacity not allocated to any pod in USD",
+			},
+			[]string{"node", "node_pool", "resource"},
+		),
+		nodePoolIdleCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_nodepool_idle_hourly_usd",
+				Help: "Hourly cost of node pool capacity not allocated to any pod in USD",
+			},
+			[]string{"node_pool", "resource"},
+		),
+		clusterIdleCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_cluster_idle_hourly_usd",
+				Help: "Hourly cost of cluster capacity not allocated to any pod in USD",
+			},
+			[]string{"resource"},
+		),
+		nodeUnitRate: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_node_resource_hourly_rate_usd",
+				Help: "Hourly node price per CPU core, GiB of memory, GPU or GiB of ephemeral storage in USD",
+			},
+			[]string{"node", "resource"},
+		),
+		accumulatedCosts: newAccumulatedCostCollector(),
+		pendingPodCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pending_pod_hourly_usd",
+				Help: "Would-be hourly cost of a pending pod on the cheapest node shape that fits it in USD",
+			},
+			[]string{"namespace", "pod", "owner_kind", "owner_name", "node_pool", "instance_type"},
+		),
+		pendingPodDuration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pending_pod_duration_seconds",
+				Help: "Time a pod has been waiting to be scheduled in seconds",
+			},
+			[]string{"namespace", "pod"},
+		),
+		namespacePendingCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_namespace_pending_hourly_usd",
+				Help: "Would-be hourly cost of a namespace's pending pods in USD",
+			},
+			[]string{"namespace"},
+		),
+		namespacePendingPods: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_namespace_pending_pods",
+				Help: "Number of pending pods in a namespace, by whether any node shape fits them",
+			},
+			[]string{"namespace", "fits"},
+		),
+		namespaceDirectCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_namespace_direct_hourly_usd",
+				Help: "Hourly cost of namespace in USD before shared cost rules are applied",
+			},
+			[]string{"namespace"},
+		),
+		namespaceSharedCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_namespace_shared_hourly_usd",
+				Help: "Hourly shared cost distributed to namespace by a shared cost rule in USD",
+			},
+			[]string{"namespace", "rule"},
+		),
+		dimensionHourlyCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_dimension_hourly_usd",
+				Help: "Hourly cost of pods by the value of an aggregation dimension in USD",
+			},
+			[]string{"dimension", "value"},
+		),
+		daemonSetFleetCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_daemonset_fleet_hourly_usd",
+				Help: "Hourly cost of a DaemonSet's pods across all nodes in USD",
+			},
+			[]string{"namespace", "daemonset"},
+		),
+		nodePoolHourlyCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_nodepool_hourly_usd",
+				Help: "Hourly cost of node pool in USD",
+			},
+			[]string{"node_pool"},
+		),
+		nodePoolNodes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_nodepool_nodes",
+				Help: "Number of nodes in node pool by capacity type",
+			},
+			[]string{"node_pool", "capacity_type"},
+		),
+		nodePoolCapacity: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_nodepool_capacity",
+				Help: "Node pool capacity in CPU cores, memory bytes or GPUs",
+			},
+			[]string{"node_pool", "resource"},
+		),
+		nodePoolAllocated: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_nodepool_allocated",
+				Help: "CPU cores, memory bytes or GPUs allocated to pods in node pool",
+			},
+			[]string{"node_pool", "resource"},
+		),
+		nodePoolSpotShare: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_nodepool_spot_ratio",
+				Help: "Fraction of node pool cost on spot nodes",
+			},
+			[]string{"node_pool"},
+		),
+		podStorageCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pod_storage_hourly_usd",
+				Help: "Hourly cost of persistent volumes mounted by pod in USD, split between pods sharing a volume",
+			},
+			[]string{"namespace", "pod", "node"},
+		),
+		podTotalCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pod_total_hourly_usd",
+				Help: "Hourly compute and persistent volume cost of pod in USD",
+			},
+			[]string{"namespace", "pod", "node"},
+		),
+		workloadStorageCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_workload_storage_hourly_usd",
+				Help: "Hourly cost of persistent volumes of workload in USD",
+			},
+			[]string{"namespace", "kind", "name"},
+		),
+		workloadTotalCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_workload_total_hourly_usd",
+				Help: "Hourly compute and persistent volume cost of workload in USD",
+			},
+			[]string{"namespace", "kind", "name"},
+		),
+		podEphemeralAllocated: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pod_ephemeral_storage_allocated_bytes",
+				Help: "Pod ephemeral storage the node root volume cost is allocated by, per the allocation mode, in bytes",
+			},
+			[]string{"namespace", "pod", "node"},
+		),
+		nodeRootVolumeCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_node_root_volume_hourly_usd",
+				Help: "Hourly cost of node root volume in USD, included in the node cost",
+			},
+			[]string{"node", "volume_type"},
+		),
+		nodeRootVolumeSize: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_node_root_volume_size_gb",
+				Help: "Size of node root volume in GB",
+			},
+			[]string{"node", "volume_type"},
+		),
+		containerCPURequest: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_container_cpu_request_cores",
+				Help: "CPU requested by app container or native sidecar in cores",
+			},
+			[]string{"namespace", "pod", "container", "sidecar"},
+		),
+		containerMemoryRequest: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_container_memory_request_bytes",
+				Help: "Memory requested by app container or native sidecar in bytes",
+			},
+			[]string{"namespace", "pod", "container", "sidecar"},
+		),
+		sidecarHourlyCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_sidecar_hourly_usd",
+				Help: "Hourly cost of sidecar containers carved out of namespace pods in USD",
+			},
+			[]string{"container", "owner", "namespace"},
+		),
+		sidecarPods: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_sidecar_pods",
+				Help: "Number of namespace pods running a sidecar container",
+			},
+			[]string{"container", "owner", "namespace"},
+		),
+		clusterSidecarCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_cluster_sidecar_hourly_usd",
+				Help: "Hourly cost of sidecar containers across the cluster in USD",
+			},
+			[]string{"container", "owner"},
+		),
 		logger: logger,
 	}
 }
@@ -124,12 +518,48 @@ func (e *Exporter) Register(registry *prometheus.Registry) error {
 	if err := registry.Register(e.podHourlyCost); err != nil {
 		return err
 	}
+	if err := registry.Register(e.podCPUUsage); err != nil {
+		return err
+	}
+	if err := registry.Register(e.podMemoryUsage); err != nil {
+		return err
+	}
+	if err := registry.Register(e.podCPUAllocated); err != nil {
+		return err
+	}
+	if err := registry.Register(e.podMemoryAllocated); err != nil {
+		return err
+	}
+	if err := registry.Register(e.podResourceCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.podStrategy); err != nil {
+		return err
+	}
+	if err := registry.Register(e.podNetworkCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.podEphemeralCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.containerHourlyCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.containerCPUUsage); err != nil {
+		return err
+	}
+	if err := registry.Register(e.containerMemUsage); err != nil {
+		return err
+	}
 	if err := registry.Register(e.namespaceHourlyCost); err != nil {
 		return err
 	}
 	if err := registry.Register(e.namespaceDailyCost); err != nil {
 		return err
 	}
+	if err := registry.Register(e.workloadHourlyCost); err != nil {
+		return err
+	}
 	if err := registry.Register(e.nodeHourlyCost); err != nil {
 		return err
 	}
@@ -160,6 +590,120 @@ func (e *Exporter) Register(registry *prometheus.Registry) error {
 	if err := registry.Register(e.namespaceSpotPercentage); err != nil {
 		return err
 	}
+	if err := registry.Register(e.nodeListHourlyCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.nodeDiscountSavings); err != nil {
+		return err
+	}
+	if err := registry.Register(e.discountSavings); err != nil {
+		return err
+	}
+	if err := registry.Register(e.commitmentCoverage); err != nil {
+		return err
+	}
+	if err := registry.Register(e.nodePricingSource); err != nil {
+		return err
+	}
+	if err := registry.Register(e.nodeArm64Equivalent); err != nil {
+		return err
+	}
+	if err := registry.Register(e.workloadArm64Cost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.workloadArm64Savings); err != nil {
+		return err
+	}
+	if err := registry.Register(e.nodeIdleCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.nodePoolIdleCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.clusterIdleCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.nodeUnitRate); err != nil {
+		return err
+	}
+	if err := registry.Register(e.accumulatedCosts); err != nil {
+		return err
+	}
+	if err := registry.Register(e.pendingPodCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.pendingPodDuration); err != nil {
+		return err
+	}
+	if err := registry.Register(e.namespacePendingCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.namespacePendingPods); err != nil {
+		return err
+	}
+	if err := registry.Register(e.namespaceDirectCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.namespaceSharedCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.dimensionHourlyCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.daemonSetFleetCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.nodePoolHourlyCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.nodePoolNodes); err != nil {
+		return err
+	}
+	if err := registry.Register(e.nodePoolCapacity); err != nil {
+		return err
+	}
+	if err := registry.Register(e.nodePoolAllocated); err != nil {
+		return err
+	}
+	if err := registry.Register(e.nodePoolSpotShare); err != nil {
+		return err
+	}
+	if err := registry.Register(e.podStorageCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.podTotalCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.workloadStorageCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.workloadTotalCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.podEphemeralAllocated); err != nil {
+		return err
+	}
+	if err := registry.Register(e.nodeRootVolumeCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.nodeRootVolumeSize); err != nil {
+		return err
+	}
+	if err := registry.Register(e.containerCPURequest); err != nil {
+		return err
+	}
+	if err := registry.Register(e.containerMemoryRequest); err != nil {
+		return err
+	}
+	if err := registry.Register(e.sidecarHourlyCost); err != nil {
+		return err
+	}
+	if err := registry.Register(e.sidecarPods); err != nil {
+		return err
+	}
+	if err := registry.Register(e.clusterSidecarCost); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -167,18 +711,98 @@ func (e *Exporter) Register(registry *prometheus.Registry) error {
 func (e *Exporter) UpdatePodMetrics(podCosts []calculator.PodCost) {
 	// Reset existing metrics
 	e.podHourlyCost.Reset()
+	e.podCPUUsage.Reset()
+	e.podMemoryUsage.Reset()
+	e.podCPUAllocated.Reset()
+	e.podMemoryAllocated.Reset()
+	e.podResourceCost.Reset()
+	e.podStrategy.Reset()
+	e.podNetworkCost.Reset()
+	e.podEphemeralCost.Reset()
+	e.podEphemeralAllocated.Reset()
+	e.containerHourlyCost.Reset()
+	e.containerCPUUsage.Reset()
+	e.containerMemUsage.Reset()
+	e.containerCPURequest.Reset()
+	e.containerMemoryRequest.Reset()
+	e.podStorageCost.Reset()
+	e.podTotalCost.Reset()
 
 	for _, podCost := range podCosts {
-		e.podHourlyCost.With(prometheus.Labels{
+		labels := prometheus.Labels{
 			"namespace": podCost.Namespace,
 			"pod":       podCost.PodName,
 			"node":      podCost.NodeName,
-		}).Set(podCost.HourlyCost)
+		}
+		e.podHourlyCost.With(labels).Set(podCost.HourlyCost)
+		e.podTotalCost.With(labels).Set(podCost.HourlyCost + podCost.StorageCost)
+		if len(podCost.PVCs) > 0 {
+			e.podStorageCost.With(labels).Set(podCost.StorageCost)
+		}
+		e.podCPUUsage.With(labels).Set(float64(podCost.CPUUsage) / 1000)
+		e.podMemoryUsage.With(labels).Set(float64(podCost.MemoryUsage))
+		e.podCPUAllocated.With(labels).Set(float64(podCost.CPUAllocated) / 1000)
+		e.podMemoryAllocated.With(labels).Set(float64(podCost.MemoryAllocated))
+		e.podResourceCost.With(resourceLabels(labels, "cpu")).Set(podCost.CPUCost)
+		e.podResourceCost.With(resourceLabels(labels, "memory")).Set(podCost.MemoryCost)
+		if podCost.GPUAllocated > 0 {
+			e.podResourceCost.With(resourceLabels(labels, "gpu")).Set(podCost.GPUCost)
+		}
+		if podCost.EphemeralStorageAllocated > 0 {
+			e.podEphemeralAllocated.With(labels).Set(float64(podCost.EphemeralStorageAllocated))
+			e.podEphemeralCost.With(labels).Set(podCost.EphemeralStorageCost)
+		}
+		e.podStrategy.With(prometheus.Labels{
+			"namespace": podCost.Namespace,
+			"pod":       podCost.PodName,
+			"strategy":  podCost.Strategy,
+		}).Set(1)
+
+		for _, container := range podCost.Containers {
+			containerLabels := prometheus.Labels{
+				"namespace": podCost.Namespace,
+				"pod":       podCost.PodName,
+				"container": container.Name,
+			}
+			e.containerHourlyCost.With(containerLabels).Set(container.HourlyCost)
+
+			sidecarLabel := "false"
+			if container.Sidecar {
+				sidecarLabel = "true"
+			}
+			requestLabels := prometheus.Labels{
+				"namespace": podCost.Namespace,
+				"pod":       podCost.PodName,
+				"container": container.Name,
+				"sidecar":   sidecarLabel,
+			}
+			e.containerCPURequest.With(requestLabels).Set(float64(container.CPURequest) / 1000)
+			e.containerMemoryRequest.With(requestLabels).Set(float64(container.MemoryRequest))
+
+			if container.HasUsage {
+				e.containerCPUUsage.With(containerLabels).Set(float64(container.CPUUsage) / 1000)
+				e.containerMemUsage.With(containerLabels).Set(float64(container.MemoryUsage))
+			}
+		}
+
+		// Kubelet statistics are only available when the kubelet collector is enabled
+		if len(podCost.Containers) > 0 && podCost.Containers[0].HasUsage {
+			e.podNetworkCost.With(labels).Set(podCost.NetworkCost)
+		}
 	}
 
 	e.logger.Infof("Updated metrics for %d pods", len(podCosts))
 }
 
+// resourceLabels returns a copy of labels with the resource label set
+func resourceLabels(labels prometheus.Labels, resource string) prometheus.Labels {
+	result := prometheus.Labels{"resource": resource}
+	for name, value := range labels {
+		result[name] = value
+	}
+	return result
+}
+
 // UpdateNamespaceMetrics updates namespace cost metrics
 func (e *Exporter) UpdateNamespaceMetrics(namespaceCosts []calculator.NamespaceCost) {
 	// Reset existing metrics
@@ -198,10 +822,112 @@ func (e *Exporter) UpdateNamespaceMetrics(namespaceCosts []calculator.NamespaceC
 	e.logger.Infof("Updated metrics for %d namespaces", len(namespaceCosts))
 }
 
+// UpdateSharedCostMetrics updates namespace costs before shared cost rules and the
+// shared costs distributed to each namespace
+func (e *Exporter) UpdateSharedCostMetrics(directCosts []calculator.NamespaceCost, shares []calculator.SharedCost) {
+	// Reset existing metrics
+	e.namespaceDirectCost.Reset()
+	e.namespaceSharedCost.Reset()
+
+	for _, nsCost := range directCosts {
+		e.namespaceDirectCost.With(prometheus.Labels{
+			"namespace": nsCost.Namespace,
+		}).Set(nsCost.HourlyCost)
+	}
+
+	for _, share := range shares {
+		e.namespaceSharedCost.With(prometheus.Labels{
+			"namespace": share.Namespace,
+			"rule":      share.Rule,
+		}).Set(share.HourlyCost)
+	}
+}
+
+// UpdateDimensionMetrics updates aggregation dimension cost metrics
+func (e *Exporter) UpdateDimensionMetrics(dimensionCosts []calculator.DimensionCost) {
+	// Reset existing metrics
+	e.dimensionHourlyCost.Reset()
+
+	for _, cost := range dimensionCosts {
+		e.dimensionHourlyCost.With(prometheus.Labels{
+			"dimension": cost.Dimension,
+			"value":     cost.Value,
+		}).Set(cost.HourlyCost)
+	}
+}
+
+// UpdateDaemonSetMetrics updates DaemonSet fleet cost metrics
+func (e *Exporter) UpdateDaemonSetMetrics(daemonSetCosts []calculator.DaemonSetCost) {
+	// Reset existing metrics
+	e.daemonSetFleetCost.Reset()
+
+	for _, daemonSet := range daemonSetCosts {
+		e.daemonSetFleetCost.With(prometheus.Labels{
+			"namespace": daemonSet.Namespace,
+			"daemonset": daemonSet.Name,
+		}).Set(daemonSet.HourlyCost)
+	}
+}
+
+// UpdateSidecarMetrics updates sidecar cost metrics per namespace and across the
+// cluster
+func (e *Exporter) UpdateSidecarMetrics(sidecarCosts []calculator.SidecarCost) {
+	// Reset existing metrics
+	e.sidecarHourlyCost.Reset()
+	e.sidecarPods.Reset()
+	e.clusterSidecarCost.Reset()
+
+	type clusterKey struct{ container, owner string }
+	clusterCosts := make(map[clusterKey]float64)
+	for _, sidecar := range sidecarCosts {
+		labels := prometheus.Labels{
+			"container": sidecar.Container,
+			"owner":     sidecar.Owner,
+			"namespace": sidecar.Namespace,
+		}
+		e.sidecarHourlyCost.With(labels).Set(sidecar.HourlyCost)
+		e.sidecarPods.With(labels).Set(float64(sidecar.PodCount))
+		clusterCosts[clusterKey{sidecar.Container, sidecar.Owner}] += sidecar.HourlyCost
+	}
+
+	for key, cost := range clusterCosts {
+		e.clusterSidecarCost.With(prometheus.Labels{"container": key.container, "owner": key.owner}).Set(cost)
+	}
+}
+
+// UpdateWorkloadMetrics updates workload cost metrics
+func (e *Exporter) UpdateWorkloadMetrics(workloadCosts []calculator.WorkloadCost) {
+	// Reset existing metrics
+	e.workloadHourlyCost.Reset()
+	e.workloadStorageCost.Reset()
+	e.workloadTotalCost.Reset()
+
+	for _, workload := range workloadCosts {
+		labels := prometheus.Labels{
+			"namespace": workload.Namespace,
+			"kind":      workload.Kind,
+			"name":      workload.Name,
+		}
+		// Workloads with storage but no running pods have no compute cost
+		if workload.PodCount > 0 {
+			e.workloadHourlyCost.With(labels).Set(workload.HourlyCost)
+		}
+		if workload.StorageCost > 0 {
+			e.workloadStorageCost.With(labels).Set(workload.StorageCost)
+		}
+		e.workloadTotalCost.With(labels).Set(workload.HourlyCost + workload.StorageCost)
+	}
+
+	e.logger.Infof("Updated metrics for %d workloads", len(workloadCosts))
+}
+
 // UpdateNodeMetrics updates node cost metrics
 func (e *Exporter) UpdateNodeMetrics(nodes []collector.NodeInfo) {
 	// Reset existing metrics
 	e.nodeHourlyCost.Reset()
+	e.nodePricingSource.Reset()
+	e.nodeRootVolumeCost.Reset()
+	e.nodeRootVolumeSize.Reset()
 
 	for _, node := range nodes {
 		spotLabel := "false"
@@ -211,9 +937,25 @@ func (e *Exporter) UpdateNodeMetrics(nodes []collector.NodeInfo) {
 
 		e.nodeHourlyCost.With(prometheus.Labels{
 			"node":          node.Name,
+			"provider":      node.CloudProvider,
 			"instance_type": node.InstanceType,
+			"arch":          node.Architecture,
 			"is_spot":       spotLabel,
-		}).Set(node.HourlyPrice)
+		}).Set(node.TotalHourlyCost())
+
+		if node.PricingSource != "" {
+			e.nodePricingSource.With(prometheus.Labels{
+				"node":          node.Name,
+				"source":        node.PricingSource,
+				"instance_type": node.InstanceType,
+			}).Set(1)
+		}
+
+		if node.RootVolumeType != "" {
+			volumeLabels := prometheus.Labels{"node": node.Name, "volume_type": node.RootVolumeType}
+			e.nodeRootVolumeCost.With(volumeLabels).Set(node.RootVolumeHourlyCost())
+			e.nodeRootVolumeSize.With(volumeLabels).Set(node.RootVolumeSizeGB)
+		}
 	}
 
 	e.logger.Infof("Updated metrics for %d nodes", len(nodes))
@@ -258,3 +1000,200 @@ func (e *Exporter) UpdateNamespaceSpotMetrics(namespaceSpotUsage []calculator.Na
 
 	e.logger.Infof("Updated spot metrics for %d namespaces", len(namespaceSpotUsage))
 }
+
+// UpdateArm64Metrics updates arm64 equivalent cost metrics for nodes and workloads
+func (e *Exporter) UpdateArm64Metrics(nodes []collector.NodeInfo, workloads []calculator.WorkloadArm64Equivalent) {
+	// Reset existing metrics
+	e.nodeArm64Equivalent.Reset()
+	e.workloadArm64Cost.Reset()
+	e.workloadArm64Savings.Reset()
+
+	for _, node := range nodes {
+		if node.Arm64EquivalentType == "" {
+			continue
+		}
+		e.nodeArm64Equivalent.With(prometheus.Labels{
+			"node":                node.Name,
+			"instance_type":       node.InstanceType,
+			"arm64_instance_type": node.Arm64EquivalentType,
+		}).Set(node.Arm64EquivalentPrice)
+	}
+
+	var totalSavings float64
+	for _, workload := range workloads {
+		labels := prometheus.Labels{
+			"namespace":  workload.Namespace,
+			"owner_kind": workload.OwnerKind,
+			"owner_name": workload.OwnerName,
+		}
+		e.workloadArm64Cost.With(labels).Set(workload.Arm64Cost)
+		e.workloadArm64Savings.With(labels).Set(workload.SavingsHourly)
+		totalSavings += workload.SavingsHourly
+	}
+
+	e.logger.Infof("Updated arm64 metrics for %d workloads: potential savings=$%.2f/hr", len(workloads), totalSavings)
+}
+
+// UpdateNodePoolMetrics updates node pool cost, capacity and allocation metrics
+func (e *Exporter) UpdateNodePoolMetrics(pools []calculator.NodePoolCost) {
+	// Reset existing metrics
+	e.nodePoolHourlyCost.Reset()
+	e.nodePoolNodes.Reset()
+	e.nodePoolCapacity.Reset()
+	e.nodePoolAllocated.Reset()
+	e.nodePoolSpotShare.Reset()
+
+	for _, pool := range pools {
+		e.nodePoolHourlyCost.With(prometheus.Labels{"node_pool": pool.NodePool}).Set(pool.HourlyCost)
+		e.nodePoolSpotShare.With(prometheus.Labels{"node_pool": pool.NodePool}).Set(pool.SpotShare())
+
+		e.nodePoolNodes.With(prometheus.Labels{"node_pool": pool.NodePool, "capacity_type": "spot"}).Set(float64(pool.SpotNodeCount))
+		e.nodePoolNodes.With(prometheus.Labels{"node_pool": pool.NodePool, "capacity_type": "on-demand"}).Set(float64(pool.NodeCount - pool.SpotNodeCount))
+
+		e.nodePoolCapacity.With(prometheus.Labels{"node_pool": pool.NodePool, "resource": "cpu"}).Set(float64(pool.CPUCapacity) / 1000)
+		e.nodePoolCapacity.With(prometheus.Labels{"node_pool": pool.NodePool, "resource": "memory"}).Set(float64(pool.MemoryCapacity))
+		e.nodePoolCapacity.With(prometheus.Labels{"node_pool": pool.NodePool, "resource": "gpu"}).Set(float64(pool.GPUCapacity))
+
+		e.nodePoolAllocated.With(prometheus.Labels{"node_pool": pool.NodePool, "resource": "cpu"}).Set(float64(pool.CPUAllocated) / 1000)
+		e.nodePoolAllocated.With(prometheus.Labels{"node_pool": pool.NodePool, "resource": "memory"}).Set(float64(pool.MemoryAllocated))
+		e.nodePoolAllocated.With(prometheus.Labels{"node_pool": pool.NodePool, "resource": "gpu"}).Set(float64(pool.GPUAllocated))
+	}
+
+	e.logger.Infof("Updated metrics for %d node pools", len(pools))
+}
+
+// UpdateIdleMetrics updates idle cost metrics for nodes, node pools and the cluster
+func (e *Exporter) UpdateIdleMetrics(nodes, nodePools []calculator.IdleCost, cluster calculator.IdleCost) {
+	// Reset existing metrics
+	e.nodeIdleCost.Reset()
+	e.nodePoolIdleCost.Reset()
+	e.clusterIdleCost.Reset()
+
+	for _, idle := range nodes {
+		e.nodeIdleCost.With(prometheus.Labels{"node": idle.Node, "node_pool": idle.NodePool, "resource": "cpu"}).Set(idle.CPUCost)
+		e.nodeIdleCost.With(prometheus.Labels{"node": idle.Node, "node_pool": idle.NodePool, "resource": "memory"}).Set(idle.MemoryCost)
+		e.nodeIdleCost.With(prometheus.Labels{"node": idle.Node, "node_pool": idle.NodePool, "resource": "gpu"}).Set(idle.GPUCost)
+		e.nodeIdleCost.With(prometheus.Labels{"node": idle.Node, "node_pool": idle.NodePool, "resource": "ephemeral-storage"}).Set(idle.EphemeralStorageCost)
+	}
+
+	for _, idle := range nodePools {
+		e.nodePoolIdleCost.With(prometheus.Labels{"node_pool": idle.NodePool, "resource": "cpu"}).Set(idle.CPUCost)
+		e.nodePoolIdleCost.With(prometheus.Labels{"node_pool": idle.NodePool, "resource": "memory"}).Set(idle.MemoryCost)
+		e.nodePoolIdleCost.With(prometheus.Labels{"node_pool": idle.NodePool, "resource": "gpu"}).Set(idle.GPUCost)
+		e.nodePoolIdleCost.With(prometheus.Labels{"node_pool": idle.NodePool, "resource": "ephemeral-storage"}).Set(idle.EphemeralStorageCost)
+	}
+
+	e.clusterIdleCost.With(prometheus.Labels{"resource": "cpu"}).Set(cluster.CPUCost)
+	e.clusterIdleCost.With(prometheus.Labels{"resource": "memory"}).Set(cluster.MemoryCost)
+	e.clusterIdleCost.With(prometheus.Labels{"resource": "gpu"}).Set(cluster.GPUCost)
+	e.clusterIdleCost.With(prometheus.Labels{"resource": "ephemeral-storage"}).Set(cluster.EphemeralStorageCost)
+
+	e.logger.Infof("Updated idle metrics for %d nodes: cluster idle=$%.2f/hr", len(nodes), cluster.HourlyCost)
+}
+
+// UpdateUnitRateMetrics updates node unit rate metrics, keyed by node name
+func (e *Exporter) UpdateUnitRateMetrics(rates map[string]calculator.UnitRates) {
+	// Reset existing metrics
+	e.nodeUnitRate.Reset()
+
+	for node, rate := range rates {
+		e.nodeUnitRate.With(prometheus.Labels{"node": node, "resource": "cpu"}).Set(rate.CPUCoreHourly)
+		e.nodeUnitRate.With(prometheus.Labels{"node": node, "resource": "memory"}).Set(rate.MemoryGiBHourly)
+		e.nodeUnitRate.With(prometheus.Labels{"node": node, "resource": "gpu"}).Set(rate.GPUHourly)
+		e.nodeUnitRate.With(prometheus.Labels{"node": node, "resource": "ephemeral-storage"}).Set(rate.EphemeralStorageGiBHourly)
+	}
+}
+
+// UpdateAccumulatedMetrics updates total cost counters. The counters are exported
+// from a snapshot of the accumulated totals, replaced in one step each cycle;
+// series past retention are dropped.
+func (e *Exporter) UpdateAccumulatedMetrics(accumulator *calculator.CostAccumulator) {
+	pods := accumulator.Pods()
+	e.accumulatedCosts.update(pods, accumulator.Namespaces(), accumulator.Nodes())
+
+	e.logger.Infof("Updated accumulated cost metrics for %d pods", len(pods))
+}
+
+// UpdatePendingMetrics updates pending pod and namespace demand metrics
+func (e *Exporter) UpdatePendingMetrics(pendingCosts []calculator.PendingPodCost, namespaces []calculator.NamespacePendingCost) {
+	// Reset existing metrics
+	e.pendingPodCost.Reset()
+	e.pendingPodDuration.Reset()
+	e.namespacePendingCost.Reset()
+	e.namespacePendingPods.Reset()
+
+	for _, pending := range pendingCosts {
+		e.pendingPodDuration.With(prometheus.Labels{
+			"namespace": pending.Namespace,
+			"pod":       pending.PodName,
+		}).Set(pending.PendingDuration.Seconds())
+
+		if pending.InstanceType == "" {
+			continue
+		}
+		e.pendingPodCost.With(prometheus.Labels{
+			"namespace":     pending.Namespace,
+			"pod":           pending.PodName,
+			"owner_kind":    pending.OwnerKind,
+			"owner_name":    pending.OwnerName,
+			"node_pool":     pending.NodePool,
+			"instance_type": pending.InstanceType,
+		}).Set(pending.HourlyCost)
+	}
+
+	for _, ns := range namespaces {
+		e.namespacePendingCost.With(prometheus.Labels{"namespace": ns.Namespace}).Set(ns.HourlyCost)
+		e.namespacePendingPods.With(prometheus.Labels{"namespace": ns.Namespace, "fits": "true"}).Set(float64(ns.PodCount - ns.Unschedulable))
+		e.namespacePendingPods.With(prometheus.Labels{"namespace": ns.Namespace, "fits": "false"}).Set(float64(ns.Unschedulable))
+	}
+
+	if len(pendingCosts) > 0 {
+		e.logger.Infof("Updated pending metrics for %d pods", len(pendingCosts))
+	}
+}
+
+// UpdateDiscountMetrics updates list price, discount savings and commitment coverage metrics
+func (e *Exporter) UpdateDiscountMetrics(nodes []collector.NodeInfo, summary calculator.DiscountSummary) {
+	// Reset existing metrics
+	e.nodeListHourlyCost.Reset()
+	e.nodeDiscountSavings.Reset()
+	e.commitmentCoverage.Reset()
+
+	for _, node := range nodes {
+		e.nodeListHourlyCost.With(prometheus.Labels{
+			"node": node.Name,
+		}).Set(node.ListPrice)
+	}
+
+	for _, discount := range summary.Nodes {
+		e.nodeDiscountSavings.With(prometheus.Labels{
+			"node":          discount.NodeName,
+			"discount_type": "sustained_use",
+		}).Set(discount.SustainedUseSavings)
+
+		e.nodeDiscountSavings.With(prometheus.Labels{
+			"node":          discount.NodeName,
+			"discount_type": "committed_use",
+		}).Set(discount.CommittedUseSavings)
+	}
+
+	e.discountSavings.With(prometheus.Labels{"discount_type": "sustained_use"}).Set(summary.SustainedUseSavingsHourly)
+	e.discountSavings.With(prometheus.Labels{"discount_type": "committed_use"}).Set(summary.CommittedUseSavingsHourly)
+
+	for _, coverage := range summary.Coverage {
+		e.commitmentCoverage.With(prometheus.Labels{
+			"region":   coverage.Region,
+			"family":   coverage.Family,
+			"resource": "cpu",
+		}).Set(coverage.VCPUCoverage)
+
+		e.commitmentCoverage.With(prometheus.Labels{
+			"region":   coverage.Region,
+			"family":   coverage.Family,
+			"resource": "memory",
+		}).Set(coverage.MemoryCoverage)
+	}
+
+	e.logger.Infof("Updated discount metrics: sustained use=$%.2f/hr, committed use=$%.2f/hr",
+		summary.SustainedUseSavingsHourly, summary.CommittedUseSavingsHourly)
+}
@@ -11,6 +11,9 @@ type StorageMetrics struct {
 	namespaceStorageCost    *prometheus.GaugeVec
 	clusterStorageCost      prometheus.Gauge
 	storageClassCost        *prometheus.GaugeVec
+	wastedVolumeCost        *prometheus.GaugeVec
+	wastedVolumeAge         *prometheus.GaugeVec
+	clusterWastedCost       *prometheus.GaugeVec
 }
 
 // NewStorageMetrics creates new storage metrics
@@ -43,6 +46,27 @@ func NewStorageMetrics() *StorageMetrics {
 			},
 			[]string{"storage_class"},
 		),
+		wastedVolumeCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pv_waste_monthly_usd",
+				Help: "Monthly cost of persistent volume that is unbound, released or unmounted in USD",
+			},
+			[]string{"pv_name", "namespace", "pvc_name", "storage_class", "reason"},
+		),
+		wastedVolumeAge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_pv_waste_age_seconds",
+				Help: "Time persistent volume has been unbound, released or unmounted in seconds, with unmounted time counted from agent start at most",
+			},
+			[]string{"pv_name", "namespace", "pvc_name", "reason"},
+		),
+		clusterWastedCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kube_cost_cluster_storage_waste_monthly_usd",
+				Help: "Total monthly cost of unbound, released and unmounted persistent volumes in USD",
+			},
+			[]string{"reason"},
+		),
 	}
 }
 
@@ -60,6 +84,15 @@ func (sm *StorageMetrics) Register(registry *prometheus.Registry) error {
 	if err := registry.Register(sm.storageClassCost); err != nil {
 		return err
 	}
+	if err := registry.Register(sm.wastedVolumeCost); err != nil {
+		return err
+	}
+	if err := registry.Register(sm.wastedVolumeAge); err != nil {
+		return err
+	}
+	if err := registry.Register(sm.clusterWastedCost); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -104,3 +137,40 @@ func (sm *StorageMetrics) UpdateNamespaceStorageMetrics(namespaceCosts []calcula
 func (sm *StorageMetrics) UpdateClusterStorageMetrics(totalCost float64) {
 	sm.clusterStorageCost.Set(totalCost)
 }
+
+// UpdateWasteMetrics updates wasted volume metrics
+func (sm *StorageMetrics) UpdateWasteMetrics(wasted []calculator.WastedVolume) {
+	sm.wastedVolumeCost.Reset()
+	sm.wastedVolumeAge.Reset()
+	sm.clusterWastedCost.Reset()
+
+	totals := map[string]float64{
+		calculator.WasteUnbound:   0,
+		calculator.WasteReleased:  0,
+		calculator.WasteRetained:  0,
+		calculator.WasteUnmounted: 0,
+	}
+
+	for _, volume := range wasted {
+		sm.wastedVolumeCost.With(prometheus.Labels{
+			"pv_name":       volume.PVName,
+			"namespace":     volume.Namespace,
+			"pvc_name":      volume.PVCName,
+			"storage_class": volume.StorageClass,
+			"reason":        volume.Reason,
+		}).Set(volume.MonthlyCost)
+
+		sm.wastedVolumeAge.With(prometheus.Labels{
+			"pv_name":   volume.PVName,
+			"namespace": volume.Namespace,
+			"pvc_name":  volume.PVCName,
+			"reason":    volume.Reason,
+		}).Set(volume.Age.Seconds())
+
+		totals[volume.Reason] += volume.MonthlyCost
+	}
+
+	for reason, total := range totals {
+		sm.clusterWastedCost.With(prometheus.Labels{"reason": reason}).Set(total)
+	}
+}
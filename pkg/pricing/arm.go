@@ -0,0 +1,119 @@
+package pricing
+
+import (
+	"regexp"
+	"strings"
+)
+
+// awsGravitonFamily matches Graviton instance families, which have a "g" after the
+// generation digit (t4g, m6g, m7gd, c7gn, x2gd, ...)
+var awsGravitonFamily = regexp.MustCompile(`^[a-z]+[0-9]+[a-z]*g[a-z]*$`)
+
+// azureArmSize matches Ampere Altra VM sizes, which have a "p" in the size
+// suffix (Standard_D4ps_v5, Standard_E8pds_v5, ...)
+var azureArmSize = regexp.MustCompile(`^Standard_[A-Z]+[0-9]+[a-z]*p[a-z]*_v[0-9]+$`)
+
+// gcpArmFamilies are GCE machine families with Arm CPUs
+var gcpArmFamilies = map[string]bool{
+	"t2a": true,
+	"c4a": true,
+}
+
+// awsArmFamilies maps x86 instance families to their closest Graviton family
+var awsArmFamilies = map[string]string{
+	"t3":  "t4g",
+	"t3a": "t4g",
+	"m5":  "m6g",
+	"m5a": "m6g",
+	"m6i": "m6g",
+	"m6a": "m6g",
+	"m7i": "m7g",
+	"m7a": "m7g",
+	"c5":  "c6g",
+	"c5a": "c6g",
+	"c6i": "c6g",
+	"c6a": "c6g",
+	"c7i": "c7g",
+	"c7a": "c7g",
+	"r5":  "r6g",
+	"r5a": "r6g",
+	"r6i": "r6g",
+	"r6a": "r6g",
+}
+
+// gcpArmSourceFamilies are x86 GCE families whose standard shapes map to t2a
+var gcpArmSourceFamilies = map[string]bool{
+	"n1":  true,
+	"n2":  true,
+	"n2d": true,
+	"e2":  true,
+	"t2d": true,
+}
+
+// azureArmSeries matches x86 D-series sizes, which map to the Dpsv5 series
+var azureArmSeries = regexp.MustCompile(`^Standard_D([0-9]+)a?s_v[345]$`)
+
+// AWSArchitecture returns arm64 for Graviton instance types and amd64 otherwise
+func AWSArchitecture(instanceType string) string {
+	family := strings.SplitN(instanceType, ".", 2)[0]
+	if awsGravitonFamily.MatchString(family) {
+		return "arm64"
+	}
+	return "amd64"
+}
+
+// GCPArchitecture returns arm64 for Arm machine families and amd64 otherwise
+func GCPArchitecture(instanceType string) string {
+	if gcpArmFamilies[GCPMachineFamily(instanceType)] {
+		return "arm64"
+	}
+	return "amd64"
+}
+
+// AzureArchitecture returns arm64 for Ampere VM sizes and amd64 otherwise
+func AzureArchitecture(instanceType string) string {
+	if azureArmSize.MatchString(instanceType) {
+		return "arm64"
+	}
+	return "amd64"
+}
+
+// Arm64Equivalent returns the arm64 instance type closest to an amd64 instance
+// type. Known family mappings are used for AWS, GCP, Azure and Oracle; other
+// providers use the nearest arm64 shape in their catalog. When a catalog is
+// available the equivalent must be listed in it.
+func Arm64Equivalent(provider, instanceType string, catalog []InstanceShape, vcpus, memoryGiB float64) (string, bool) {
+	var equivalent string
+
+	switch provider {
+	case "aws":
+		parts := strings.SplitN(instanceType, ".", 2)
+		if family, ok := awsArmFamilies[parts[0]]; ok && len(parts) == 2 {
+			equivalent = family + "." + parts[1]
+		}
+	case "gcp":
+		parts := strings.Split(instanceType, "-")
+		if len(parts) == 3 && gcpArmSourceFamilies[parts[0]] && parts[1] == "standard" {
+			equivalent = "t2a-standard-" + parts[2]
+		}
+	case "azure":
+		if matches := azureArmSeries.FindStringSubmatch(instanceType); matches != nil {
+			equivalent = "Standard_D" + matches[1] + "ps_v5"
+		}
+	case "oracle":
+		// Ampere A1 is flexible, so it can match the x86 shape's size exactly
+		if strings.HasPrefix(instanceType, "VM.Standard") && !strings.Contains(instanceType, ".A1.") {
+			return "VM.Standard.A1.Flex", true
+		}
+		return "", false
+	}
+
+	if equivalent != "" && (len(catalog) == 0 || HasInstanceType(catalog, equivalent)) {
+		return equivalent, true
+	}
+
+	if shape, ok := NearestInstanceShape(catalog, vcpus, memoryGiB, 0, "arm64"); ok {
+		return shape.Name, true
+	}
+	return "", false
+}
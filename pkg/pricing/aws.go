@@ -98,12 +98,12 @@ func (a *AWSProvider) GetInstancePrice(ctx context.Context, instanceType, region
 	result, err := a.pricingClient.GetProducts(ctx, input)
 	if err != nil {
 		a.logger.Warnf("Failed to get pricing from API for %s: %v, using fallback", instanceType, err)
-		return a.getFallbackPrice(instanceType), nil
+		return a.getFallbackPrice(instanceType)
 	}
 
 	if len(result.PriceList) == 0 {
 		a.logger.Warnf("No pricing found for %s, using fallback", instanceType)
-		return a.getFallbackPrice(instanceType), nil
+		return a.getFallbackPrice(instanceType)
 	}
 
 	// Parse the pricing JSON
@@ -116,7 +116,7 @@ func (a *AWSProvider) GetInstancePrice(ctx context.Context, instanceType, region
 	price, err := a.extractOnDemandPrice(priceData)
 	if err != nil {
 		a.logger.Warnf("Failed to extract price for %s: %v, using fallback", instanceType, err)
-		return a.getFallbackPrice(instanceType), nil
+		return a.getFallbackPrice(instanceType)
 	}
 
 	return price, nil
@@ -134,7 +134,12 @@ func (a *AWSProvider) GetSpotPrice(ctx context.Context, instanceType, region, az
 		input.AvailabilityZone = aws.String(az)
 	}
 
-	result, err := a.ec2Client.DescribeSpotPriceHistory(ctx, input)
+	// Spot price history is regional, so query the node's region rather than the client default
+	result, err := a.ec2Client.DescribeSpotPriceHistory(ctx, input, func(o *ec2.Options) {
+		if region != "" {
+			o.Region = region
+		}
+	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to get spot price: %w", err)
 	}
@@ -183,6 +188,198 @@ func (a *AWSProvider) GetNetworkPrice(ctx context.Context, region, destination s
 	return 0.09, nil
 }
 
+// Fallback prices for common instance types (hourly USD)
+var awsFallbackPrices = map[string]float64{
+	// t3 family
+	"t3.micro":   0.0104,
+	"t3.small":   0.0208,
+	"t3.medium":  0.0416,
+	"t3.large":   0.0832,
+	"t3.xlarge":  0.1664,
+	"t3.2xlarge": 0.3328,
+
+	// m5 family
+	"m5.large":    0.096,
+	"m5.xlarge":   0.192,
+	"m5.2xlarge":  0.384,
+	"m5.4xlarge":  0.768,
+	"m5.8xlarge":  1.536,
+	"m5.12xlarge": 2.304,
+	"m5.16xlarge": 3.072,
+	"m5.24xlarge": 4.608,
+
+	// c5 family
+	"c5.large":    0.085,
+	"c5.xlarge":   0.17,
+	"c5.2xlarge":  0.34,
+	"c5.4xlarge":  0.68,
+	"c5.9xlarge":  1.53,
+	"c5.12xlarge": 2.04,
+	"c5.18xlarge": 3.06,
+	"c5.24xlarge": 4.08,
+
+	// r5 family
+	"r5.large":    0.126,
+	"r5.xlarge":   0.252,
+	"r5.2xlarge":  0.504,
+	"r5.4xlarge":  1.008,
+	"r5.8xlarge":  2.016,
+	"r5.12xlarge": 3.024,
+	"r5.16xlarge": 4.032,
+	"r5.24xlarge": 6.048,
+
+	// t4g family (Graviton2, burstable)
+	"t4g.micro":   0.0084,
+	"t4g.small":   0.0168,
+	"t4g.medium":  0.0336,
+	"t4g.large":   0.0672,
+	"t4g.xlarge":  0.1344,
+	"t4g.2xlarge": 0.2688,
+
+	// m6g family (Graviton2)
+	"m6g.medium":   0.0385,
+	"m6g.large":    0.077,
+	"m6g.xlarge":   0.154,
+	"m6g.2xlarge":  0.308,
+	"m6g.4xlarge":  0.616,
+	"m6g.8xlarge":  1.232,
+	"m6g.12xlarge": 1.848,
+	"m6g.16xlarge": 2.464,
+
+	// m7g family (Graviton3)
+	"m7g.medium":   0.0408,
+	"m7g.large":    0.0816,
+	"m7g.xlarge":   0.1632,
+	"m7g.2xlarge":  0.3264,
+	"m7g.4xlarge":  0.6528,
+	"m7g.8xlarge":  1.3056,
+	"m7g.12xlarge": 1.9584,
+	"m7g.16xlarge": 2.6112,
+
+	// c6g family (Graviton2)
+	"c6g.medium":   0.034,
+	"c6g.large":    0.068,
+	"c6g.xlarge":   0.136,
+	"c6g.2xlarge":  0.272,
+	"c6g.4xlarge":  0.544,
+	"c6g.8xlarge":  1.088,
+	"c6g.12xlarge": 1.632,
+	"c6g.16xlarge": 2.176,
+
+	// c7g family (Graviton3)
+	"c7g.medium":   0.0363,
+	"c7g.large":    0.0725,
+	"c7g.xlarge":   0.145,
+	"c7g.2xlarge":  0.29,
+	"c7g.4xlarge":  0.58,
+	"c7g.8xlarge":  1.16,
+	"c7g.12xlarge": 1.74,
+	"c7g.16xlarge": 2.32,
+
+	// r6g family (Graviton2)
+	"r6g.medium":   0.0504,
+	"r6g.large":    0.1008,
+	"r6g.xlarge":   0.2016,
+	"r6g.2xlarge":  0.4032,
+	"r6g.4xlarge":  0.8064,
+	"r6g.8xlarge":  1.6128,
+	"r6g.12xlarge": 2.4192,
+	"r6g.16xlarge": 3.2256,
+}
+
+// awsSizeVCPUs maps instance sizes to vCPU counts; <N>xlarge sizes have 4*N vCPUs
+var awsSizeVCPUs = map[string]float64{
+	"nano":   2,
+	"micro":  2,
+	"small":  2,
+	"medium": 2,
+	"large":  2,
+	"xlarge": 4,
+}
+
+// awsFamilyMemoryPerVCPU maps instance family classes to GiB of memory per vCPU
+var awsFamilyMemoryPerVCPU = map[byte]float64{
+	'c': 2,
+	'm': 4,
+	'r': 8,
+	'x': 16,
+}
+
+// awsBurstableMemory maps burstable (t-family) sizes to GiB of memory
+var awsBurstableMemory = map[string]float64{
+	"nano":    0.5,
+	"micro":   1,
+	"small":   2,
+	"medium":  4,
+	"large":   8,
+	"xlarge":  16,
+	"2xlarge": 32,
+}
+
+// InstanceCatalog returns the shapes of the instance types in the fallback price table
+func (a *AWSProvider) InstanceCatalog() []InstanceShape {
+	catalog := make([]InstanceShape, 0, len(awsFallbackPrices))
+	for instanceType := range awsFallbackPrices {
+		if shape, ok := awsInstanceShape(instanceType); ok {
+			catalog = append(catalog, shape)
+		}
+	}
+	return catalog
+}
+
+// GetComponentRates returns approximate on-demand rates for general purpose instances
+func (a *AWSProvider) GetComponentRates(ctx context.Context, region string) (ComponentRates, error) {
+	return ComponentRates{
+		CPUHourly:       0.0316,
+		MemoryGiBHourly: 0.0042,
+		GPUHourly:       0.526, // g4dn (T4) GPU share
+	}, nil
+}
+
+// awsInstanceShape derives vCPUs and memory from an instance type name such as m5.2xlarge
+func awsInstanceShape(instanceType string) (InstanceShape, bool) {
+	parts := strings.SplitN(instanceType, ".", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return InstanceShape{}, false
+	}
+	family, size := parts[0], parts[1]
+
+	vcpus, ok := awsSizeVCPUs[size]
+	if size == "medium" && family[0] != 't' {
+		// Only burstable medium instances have two vCPUs
+		vcpus = 1
+	}
+	if !ok && strings.HasSuffix(size, "xlarge") {
+		multiplier, err := strconv.ParseFloat(strings.TrimSuffix(size, "xlarge"), 64)
+		if err != nil {
+			return InstanceShape{}, false
+		}
+		vcpus, ok = 4*multiplier, true
+	}
+	if !ok {
+		return InstanceShape{}, false
+	}
+
+	var memoryGiB float64
+	if family[0] == 't' {
+		memoryGiB, ok = awsBurstableMemory[size]
+	} else {
+		var perVCPU float64
+		perVCPU, ok = awsFamilyMemoryPerVCPU[family[0]]
+		memoryGiB = vcpus * perVCPU
+	}
+	if !ok {
+		return InstanceShape{}, false
+	}
+
+	return InstanceShape{
+		Name:         instanceType,
+		VCPUs:        vcpus,
+		MemoryGiB:    memoryGiB,
+		Architecture: AWSArchitecture(instanceType),
+	}, true
+}
+
 // Helper functions
 
 func (a *AWSProvider) regionToLocation(region string) string {
@@ -257,64 +454,11 @@ func (a *AWSProvider) extractOnDemandPrice(priceData map[string]interface{}) (fl
 	return 0, fmt.Errorf("could not extract price from terms")
 }
 
-func (a *AWSProvider) getFallbackPrice(instanceType string) float64 {
-	// Fallback prices for common instance types (hourly USD)
-	fallbackPrices := map[string]float64{
-		// t3 family
-		"t3.micro":   0.0104,
-		"t3.small":   0.0208,
-		"t3.medium":  0.0416,
-		"t3.large":   0.0832,
-		"t3.xlarge":  0.1664,
-		"t3.2xlarge": 0.3328,
-
-		// m5 family
-		"m5.large":    0.096,
-		"m5.xlarge":   0.192,
-		"m5.2xlarge":  0.384,
-		"m5.4xlarge":  0.768,
-		"m5.8xlarge":  1.536,
-		"m5.12xlarge": 2.304,
-		"m5.16xlarge": 3.072,
-		"m5.24xlarge": 4.608,
-
-		// c5 family
-		"c5.large":    0.085,
-		"c5.xlarge":   0.17,
-		"c5.2xlarge":  0.34,
-		"c5.4xlarge":  0.68,
-		"c5.9xlarge":  1.53,
-		"c5.12xlarge": 2.04,
-		"c5.18xlarge": 3.06,
-		"c5.24xlarge": 4.08,
-
-		// r5 family
-		"r5.large":    0.126,
-		"r5.xlarge":   0.252,
-		"r5.2xlarge":  0.504,
-		"r5.4xlarge":  1.008,
-		"r5.8xlarge":  2.016,
-		"r5.12xlarge": 3.024,
-		"r5.16xlarge": 4.032,
-		"r5.24xlarge": 6.048,
-	}
-
-	if price, ok := fallbackPrices[instanceType]; ok {
-		return price
-	}
-
-	// Estimate based on instance size if not in table
-	if strings.Contains(instanceType, "micro") {
-		return 0.01
-	} else if strings.Contains(instanceType, "small") {
-		return 0.02
-	} else if strings.Contains(instanceType, "medium") {
-		return 0.04
-	} else if strings.Contains(instanceType, "large") && !strings.Contains(instanceType, "xlarge") {
-		return 0.10
-	} else if strings.Contains(instanceType, "xlarge") {
-		return 0.20
+// getFallbackPrice returns the fallback price of common instance types. Other
+// types return an error, so the node is priced by its shape instead of a guess.
+func (a *AWSProvider) getFallbackPrice(instanceType string) (float64, error) {
+	if price, ok := awsFallbackPrices[instanceType]; ok {
+		return price, nil
 	}
-
-	return 0.10 // Default fallback
+	return 0, fmt.Errorf("no fallback price for instance type %s", instanceType)
 }
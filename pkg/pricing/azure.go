@@ -65,54 +65,125 @@ func (a *AzureProvider) GetNetworkPrice(ctx context.Context, region, destination
 	return 0.087, nil
 }
 
+// azureFallbackPrices are on-demand East US prices for common VM sizes (hourly USD)
+var azureFallbackPrices = map[string]float64{
+	// B-series (burstable)
+	"Standard_B1s":  0.0104,
+	"Standard_B1ms": 0.0207,
+	"Standard_B2s":  0.0416,
+	"Standard_B2ms": 0.0832,
+	"Standard_B4ms": 0.1664,
+	"Standard_B8ms": 0.3328,
+
+	// D-series (general purpose)
+	"Standard_D2s_v3":  0.096,
+	"Standard_D4s_v3":  0.192,
+	"Standard_D8s_v3":  0.384,
+	"Standard_D16s_v3": 0.768,
+	"Standard_D32s_v3": 1.536,
+	"Standard_D48s_v3": 2.304,
+	"Standard_D64s_v3": 3.072,
+
+	// F-series (compute-optimized)
+	"Standard_F2s_v2":  0.085,
+	"Standard_F4s_v2":  0.169,
+	"Standard_F8s_v2":  0.338,
+	"Standard_F16s_v2": 0.677,
+	"Standard_F32s_v2": 1.353,
+	"Standard_F48s_v2": 2.030,
+	"Standard_F64s_v2": 2.706,
+
+	// E-series (memory-optimized)
+	"Standard_E2s_v3":  0.126,
+	"Standard_E4s_v3":  0.252,
+	"Standard_E8s_v3":  0.504,
+	"Standard_E16s_v3": 1.008,
+	"Standard_E32s_v3": 2.016,
+	"Standard_E48s_v3": 3.024,
+	"Standard_E64s_v3": 4.032,
+
+	// N-series (GPU)
+	"Standard_NC6":    0.90,
+	"Standard_NC12":   1.80,
+	"Standard_NC24":   3.60,
+	"Standard_NC6s_v3": 3.06,
+
+	// Dpsv5-series (Ampere Altra, arm64)
+	"Standard_D2ps_v5":  0.077,
+	"Standard_D4ps_v5":  0.154,
+	"Standard_D8ps_v5":  0.308,
+	"Standard_D16ps_v5": 0.616,
+	"Standard_D32ps_v5": 1.232,
+	"Standard_D48ps_v5": 1.848,
+	"Standard_D64ps_v5": 2.464,
+}
+
+// azureInstanceShapes are the sizes of the VMs in the fallback price table
+var azureInstanceShapes = []InstanceShape{
+	{Name: "Standard_B1s", VCPUs: 1, MemoryGiB: 1},
+	{Name: "Standard_B1ms", VCPUs: 1, MemoryGiB: 2},
+	{Name: "Standard_B2s", VCPUs: 2, MemoryGiB: 4},
+	{Name: "Standard_B2ms", VCPUs: 2, MemoryGiB: 8},
+	{Name: "Standard_B4ms", VCPUs: 4, MemoryGiB: 16},
+	{Name: "Standard_B8ms", VCPUs: 8, MemoryGiB: 32},
+	{Name: "Standard_D2s_v3", VCPUs: 2, MemoryGiB: 8},
+	{Name: "Standard_D4s_v3", VCPUs: 4, MemoryGiB: 16},
+	{Name: "Standard_D8s_v3", VCPUs: 8, MemoryGiB: 32},
+	{Name: "Standard_D16s_v3", VCPUs: 16, MemoryGiB: 64},
+	{Name: "Standard_D32s_v3", VCPUs: 32, MemoryGiB: 128},
+	{Name: "Standard_D48s_v3", VCPUs: 48, MemoryGiB: 192},
+	{Name: "Standard_D64s_v3", VCPUs: 64, MemoryGiB: 256},
+	{Name: "Standard_F2s_v2", VCPUs: 2, MemoryGiB: 4},
+	{Name: "Standard_F4s_v2", VCPUs: 4, MemoryGiB: 8},
+	{Name: "Standard_F8s_v2", VCPUs: 8, MemoryGiB: 16},
+	{Name: "Standard_F16s_v2", VCPUs: 16, MemoryGiB: 32},
+	{Name: "Standard_F32s_v2", VCPUs: 32, MemoryGiB: 64},
+	{Name: "Standard_F48s_v2", VCPUs: 48, MemoryGiB: 96},
+	{Name: "Standard_F64s_v2", VCPUs: 64, MemoryGiB: 128},
+	{Name: "Standard_E2s_v3", VCPUs: 2, MemoryGiB: 16},
+	{Name: "Standard_E4s_v3", VCPUs: 4, MemoryGiB: 32},
+	{Name: "Standard_E8s_v3", VCPUs: 8, MemoryGiB: 64},
+	{Name: "Standard_E16s_v3", VCPUs: 16, MemoryGiB: 128},
+	{Name: "Standard_E32s_v3", VCPUs: 32, MemoryGiB: 256},
+	{Name: "Standard_E48s_v3", VCPUs: 48, MemoryGiB: 384},
+	{Name: "Standard_E64s_v3", VCPUs: 64, MemoryGiB: 432},
+	{Name: "Standard_NC6", VCPUs: 6, MemoryGiB: 56, GPUs: 1},
+	{Name: "Standard_NC12", VCPUs: 12, MemoryGiB: 112, GPUs: 2},
+	{Name: "Standard_NC24", VCPUs: 24, MemoryGiB: 224, GPUs: 4},
+	{Name: "Standard_NC6s_v3", VCPUs: 6, MemoryGiB: 112, GPUs: 1},
+	{Name: "Standard_D2ps_v5", VCPUs: 2, MemoryGiB: 8},
+	{Name: "Standard_D4ps_v5", VCPUs: 4, MemoryGiB: 16},
+	{Name: "Standard_D8ps_v5", VCPUs: 8, MemoryGiB: 32},
+	{Name: "Standard_D16ps_v5", VCPUs: 16, MemoryGiB: 64},
+	{Name: "Standard_D32ps_v5", VCPUs: 32, MemoryGiB: 128},
+	{Name: "Standard_D48ps_v5", VCPUs: 48, MemoryGiB: 192},
+	{Name: "Standard_D64ps_v5", VCPUs: 64, MemoryGiB: 256},
+}
+
+// InstanceCatalog returns the shapes of the VM sizes in the fallback price table
+func (a *AzureProvider) InstanceCatalog() []InstanceShape {
+	catalog := make([]InstanceShape, len(azureInstanceShapes))
+	for i, shape := range azureInstanceShapes {
+		shape.Architecture = AzureArchitecture(shape.Name)
+		catalog[i] = shape
+	}
+	return catalog
+}
+
+// GetComponentRates returns approximate on-demand rates for general purpose VMs
+func (a *AzureProvider) GetComponentRates(ctx context.Context, region string) (ComponentRates, error) {
+	return ComponentRates{
+		CPUHourly:       0.0316,
+		MemoryGiBHourly: 0.0042,
+		GPUHourly:       0.475, // NC-series K80 share
+	}, nil
+}
+
 // getFallbackPrice returns fallback pricing for common Azure instance types
 func (a *AzureProvider) getFallbackPrice(instanceType, region string) float64 {
 	// Azure VM pricing varies by series and size
 
-	fallbackPrices := map[string]float64{
-		// B-series (burstable)
-		"Standard_B1s":  0.0104,
-		"Standard_B1ms": 0.0207,
-		"Standard_B2s":  0.0416,
-		"Standard_B2ms": 0.0832,
-		"Standard_B4ms": 0.1664,
-		"Standard_B8ms": 0.3328,
-
-		// D-series (general purpose)
-		"Standard_D2s_v3":  0.096,
-		"Standard_D4s_v3":  0.192,
-		"Standard_D8s_v3":  0.384,
-		"Standard_D16s_v3": 0.768,
-		"Standard_D32s_v3": 1.536,
-		"Standard_D48s_v3": 2.304,
-		"Standard_D64s_v3": 3.072,
-
-		// F-series (compute-optimized)
-		"Standard_F2s_v2":  0.085,
-		"Standard_F4s_v2":  0.169,
-		"Standard_F8s_v2":  0.338,
-		"Standard_F16s_v2": 0.677,
-		"Standard_F32s_v2": 1.353,
-		"Standard_F48s_v2": 2.030,
-		"Standard_F64s_v2": 2.706,
-
-		// E-series (memory-optimized)
-		"Standard_E2s_v3":  0.126,
-		"Standard_E4s_v3":  0.252,
-		"Standard_E8s_v3":  0.504,
-		"Standard_E16s_v3": 1.008,
-		"Standard_E32s_v3": 2.016,
-		"Standard_E48s_v3": 3.024,
-		"Standard_E64s_v3": 4.032,
-
-		// N-series (GPU)
-		"Standard_NC6":    0.90,
-		"Standard_NC12":   1.80,
-		"Standard_NC24":   3.60,
-		"Standard_NC6s_v3": 3.06,
-	}
-
-	if price, ok := fallbackPrices[instanceType]; ok {
+	if price, ok := azureFallbackPrices[instanceType]; ok {
 		// Adjust for region
 		regionMultiplier := 1.0
 		if strings.Contains(region, "eastus") || strings.Contains(region, "westus") {
@@ -57,6 +57,43 @@ func (pc *PricingCache) GetNetworkPrice(ctx context.Context, region, destination
 	})
 }
 
+// GetFlexiblePrice returns the cached price of a flexible instance sized to the given
+// vCPUs and memory. ok is false when the provider prices the instance type by name alone.
+func (pc *PricingCache) GetFlexiblePrice(ctx context.Context, instanceType, region string, vcpus, memoryGiB float64, spot bool) (float64, bool, error) {
+	flexible, isFlexible := pc.provider.(FlexiblePricer)
+	if !isFlexible || !flexible.IsFlexible(instanceType) {
+		return 0, false, nil
+	}
+
+	key := fmt.Sprintf("flexible:%s:%s:%g:%g:%t", instanceType, region, vcpus, memoryGiB, spot)
+	ttl := 1 * time.Hour
+
+	price, err := pc.getOrFetch(ctx, key, ttl, func() (float64, error) {
+		return flexible.GetFlexiblePrice(ctx, instanceType, region, vcpus, memoryGiB, spot)
+	})
+	return price, true, err
+}
+
+// InstanceCatalog returns the provider's instance catalog, or nil if it has none
+func (pc *PricingCache) InstanceCatalog() []InstanceShape {
+	if catalog, ok := pc.provider.(Catalog); ok {
+		return catalog.InstanceCatalog()
+	}
+	return nil
+}
+
+// GetComponentRates returns the provider's per-resource rates. ok is false when the
+// provider has no component pricing.
+func (pc *PricingCache) GetComponentRates(ctx context.Context, region string) (ComponentRates, bool, error) {
+	// Component rates come from static tables, so they are not cached
+	componentPricer, ok := pc.provider.(ComponentPricer)
+	if !ok {
+		return ComponentRates{}, false, nil
+	}
+	rates, err := componentPricer.GetComponentRates(ctx, region)
+	return rates, true, err
+}
+
 // getOrFetch gets from cache or fetches and caches
 func (pc *PricingCache) getOrFetch(ctx context.Context, key string, ttl time.Duration, fetchFunc func() (float64, error)) (float64, error) {
 	// Try to get from cache
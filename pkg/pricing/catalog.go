@@ -0,0 +1,76 @@
+package pricing
+
+import (
+	"context"
+	"math"
+)
+
+// maxShapeDistance is the largest relative CPU/memory difference accepted when
+// matching a node to a catalog instance type
+const maxShapeDistance = 0.5
+
+// InstanceShape describes the resources of a catalog instance type
+type InstanceShape struct {
+	Name         string
+	VCPUs        float64
+	MemoryGiB    float64
+	GPUs         int
+	Architecture string // amd64 or arm64
+}
+
+// Catalog is implemented by providers that can list the instance types they price
+type Catalog interface {
+	InstanceCatalog() []InstanceShape
+}
+
+// ComponentPricer is implemented by providers with per-vCPU, per-GiB and per-GPU
+// prices, used to price nodes that cannot be matched to a catalog instance type
+type ComponentPricer interface {
+	GetComponentRates(ctx context.Context, region string) (ComponentRates, error)
+}
+
+// NearestInstanceShape finds the catalog instance type closest to the given capacity.
+// Shapes must match the GPU count and, when known, the architecture. Returns false if
+// no shape is within maxShapeDistance.
+func NearestInstanceShape(catalog []InstanceShape, vcpus, memoryGiB float64, gpus int, arch string) (InstanceShape, bool) {
+	var best InstanceShape
+	bestDistance := math.MaxFloat64
+
+	for _, shape := range catalog {
+		if shape.GPUs != gpus {
+			continue
+		}
+		if arch != "" && shape.Architecture != "" && shape.Architecture != arch {
+			continue
+		}
+		if shape.VCPUs <= 0 || shape.MemoryGiB <= 0 {
+			continue
+		}
+
+		// Relative distance, so small and large shapes are compared fairly. Node
+		// capacity is usually slightly below the nominal instance size.
+		cpuDiff := (vcpus - shape.VCPUs) / shape.VCPUs
+		memoryDiff := (memoryGiB - shape.MemoryGiB) / shape.MemoryGiB
+		distance := math.Sqrt(cpuDiff*cpuDiff + memoryDiff*memoryDiff)
+
+		if distance < bestDistance {
+			best = shape
+			bestDistance = distance
+		}
+	}
+
+	if bestDistance > maxShapeDistance {
+		return InstanceShape{}, false
+	}
+	return best, true
+}
+
+// HasInstanceType reports whether a catalog contains the named instance type
+func HasInstanceType(catalog []InstanceShape, instanceType string) bool {
+	for _, shape := range catalog {
+		if shape.Name == instanceType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DigitalOceanProvider implements the Provider interface for DigitalOcean
+type DigitalOceanProvider struct {
+	sizes  map[string]doSize
+	logger *logrus.Logger
+}
+
+// doSizesResponse is the format of the DigitalOcean /v2/sizes price list
+type doSizesResponse struct {
+	Sizes []doSize `json:"sizes"`
+}
+
+type doSize struct {
+	Slug         string   `json:"slug"`
+	Memory       int64    `json:"memory"` // MiB
+	VCPUs        int      `json:"vcpus"`
+	PriceMonthly float64  `json:"price_monthly"`
+	PriceHourly  float64  `json:"price_hourly"`
+	Regions      []string `json:"regions"`
+	Description  string   `json:"description"`
+}
+
+// NewDigitalOceanProvider creates a new DigitalOcean pricing provider from a
+// /v2/sizes price list. An empty path uses the bundled price list.
+func NewDigitalOceanProvider(priceListPath string) (*DigitalOceanProvider, error) {
+	data, err := readPriceList(priceListPath, "digitalocean")
+	if err != nil {
+		return nil, err
+	}
+
+	var priceList doSizesResponse
+	if err := json.Unmarshal(data, &priceList); err != nil {
+		return nil, fmt.Errorf("failed to parse DigitalOcean price list: %w", err)
+	}
+
+	sizes := make(map[string]doSize, len(priceList.Sizes))
+	for _, size := range priceList.Sizes {
+		sizes[size.Slug] = size
+	}
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+
+	return &DigitalOceanProvider{
+		sizes:  sizes,
+		logger: logger,
+	}, nil
+}
+
+// GetInstancePrice returns the hourly price for a Droplet size
+func (d *DigitalOceanProvider) GetInstancePrice(ctx context.Context, instanceType, region, az string) (float64, error) {
+	// Droplet prices are the same in every region
+	size, ok := d.sizes[instanceType]
+	if !ok {
+		return 0, fmt.Errorf("no DigitalOcean price for size %s", instanceType)
+	}
+	return size.PriceHourly, nil
+}
+
+// GetSpotPrice returns the on-demand price, as DigitalOcean has no spot Droplets
+func (d *DigitalOceanProvider) GetSpotPrice(ctx context.Context, instanceType, region, az string) (float64, error) {
+	return d.GetInstancePrice(ctx, instanceType, region, az)
+}
+
+// InstanceCatalog returns the shapes of the Droplet sizes in the price list
+func (d *DigitalOceanProvider) InstanceCatalog() []InstanceShape {
+	catalog := make([]InstanceShape, 0, len(d.sizes))
+	for _, size := range d.sizes {
+		catalog = append(catalog, InstanceShape{
+			Name:         size.Slug,
+			VCPUs:        float64(size.VCPUs),
+			MemoryGiB:    float64(size.Memory) / 1024,
+			Architecture: "amd64",
+		})
+	}
+	return catalog
+}
+
+// GetStoragePrice returns the price per GB/month for block storage volumes
+func (d *DigitalOceanProvider) GetStoragePrice(ctx context.Context, storageType, region string) (float64, error) {
+	// Volumes Block Storage has a single tier
+	return 0.10, nil
+}
+
+// GetNetworkPrice returns the price per GB for network egress
+func (d *DigitalOceanProvider) GetNetworkPrice(ctx context.Context, region, destination string) (float64, error) {
+	// Outbound transfer beyond the pooled Droplet allowance: $0.01/GB
+	return 0.01, nil
+}
@@ -0,0 +1,67 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDigitalOceanInstancePrice(t *testing.T) {
+	provider, err := NewDigitalOceanProvider("")
+	if err != nil {
+		t.Fatalf("NewDigitalOceanProvider: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		instanceType string
+		region       string
+		want         float64
+		wantErr      bool
+	}{
+		{name: "basic droplet", instanceType: "s-2vcpu-4gb", region: "nyc1", want: 0.03571},
+		{name: "same price in every region", instanceType: "s-2vcpu-4gb", region: "sgp1", want: 0.03571},
+		{name: "CPU-optimized droplet", instanceType: "c-4", region: "ams3", want: 0.125},
+		{name: "unknown size", instanceType: "s-64vcpu-1tb", region: "nyc1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.GetInstancePrice(context.Background(), tt.instanceType, tt.region, "")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GetInstancePrice(%q) = %v, want error", tt.instanceType, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetInstancePrice(%q): %v", tt.instanceType, err)
+			}
+			if !approxEqual(got, tt.want) {
+				t.Errorf("GetInstancePrice(%q) = %v, want %v", tt.instanceType, got, tt.want)
+			}
+
+			spot, err := provider.GetSpotPrice(context.Background(), tt.instanceType, tt.region, "")
+			if err != nil || !approxEqual(spot, tt.want) {
+				t.Errorf("GetSpotPrice(%q) = %v, %v, want on-demand price %v", tt.instanceType, spot, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDigitalOceanInstanceCatalog(t *testing.T) {
+	provider, err := NewDigitalOceanProvider("")
+	if err != nil {
+		t.Fatalf("NewDigitalOceanProvider: %v", err)
+	}
+
+	for _, shape := range provider.InstanceCatalog() {
+		if shape.Name != "m-2vcpu-16gb" {
+			continue
+		}
+		if shape.VCPUs != 2 || shape.MemoryGiB != 16 || shape.Architecture != "amd64" {
+			t.Errorf("catalog shape %s = %+v, want 2 vCPUs, 16 GiB, amd64", shape.Name, shape)
+		}
+		return
+	}
+	t.Error("catalog has no m-2vcpu-16gb shape")
+}
@@ -2,6 +2,7 @@ package pricing
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -67,61 +68,172 @@ func (g *GCPProvider) GetNetworkPrice(ctx context.Context, region, destination s
 	return 0.12, nil
 }
 
-// getFallbackPrice returns fallback pricing for common GCP instance types
-func (g *GCPProvider) getFallbackPrice(instanceType, region string) float64 {
-	// Extract machine family and size
-	// Format: n1-standard-1, n2-standard-4, e2-medium, etc.
+// gcpFallbackPrices are on-demand us-central1 prices for common GCE machine types (hourly USD)
+var gcpFallbackPrices = map[string]float64{
+	// e2 family (cost-optimized)
+	"e2-micro":     0.0084,
+	"e2-small":     0.0167,
+	"e2-medium":    0.0334,
+	"e2-standard-2": 0.0669,
+	"e2-standard-4": 0.1338,
+	"e2-standard-8": 0.2676,
+	"e2-standard-16": 0.5352,
+
+	// n1 family (general purpose)
+	"n1-standard-1":  0.0475,
+	"n1-standard-2":  0.0950,
+	"n1-standard-4":  0.1900,
+	"n1-standard-8":  0.3800,
+	"n1-standard-16": 0.7600,
+	"n1-standard-32": 1.5200,
+	"n1-standard-64": 3.0400,
+
+	// n2 family (newer general purpose)
+	"n2-standard-2":  0.0971,
+	"n2-standard-4":  0.1942,
+	"n2-standard-8":  0.3884,
+	"n2-standard-16": 0.7768,
+	"n2-standard-32": 1.5536,
+	"n2-standard-64": 3.1072,
+
+	// c2 family (compute-optimized)
+	"c2-standard-4":  0.2088,
+	"c2-standard-8":  0.4176,
+	"c2-standard-16": 0.8352,
+	"c2-standard-30": 1.5660,
+	"c2-standard-60": 3.1320,
+
+	// m1 family (memory-optimized)
+	"m1-megamem-96":   10.6740,
+	"m1-ultramem-40":  6.3039,
+	"m1-ultramem-80":  12.6078,
+	"m1-ultramem-160": 25.2156,
+
+	// t2a family (Ampere Altra, arm64)
+	"t2a-standard-1":  0.0385,
+	"t2a-standard-2":  0.077,
+	"t2a-standard-4":  0.154,
+	"t2a-standard-8":  0.308,
+	"t2a-standard-16": 0.616,
+	"t2a-standard-32": 1.232,
+	"t2a-standard-48": 1.848,
+}
 
-	fallbackPrices := map[string]float64{
-		// e2 family (cost-optimized)
-		"e2-micro":     0.0084,
-		"e2-small":     0.0167,
-		"e2-medium":    0.0334,
-		"e2-standard-2": 0.0669,
-		"e2-standard-4": 0.1338,
-		"e2-standard-8": 0.2676,
-		"e2-standard-16": 0.5352,
-
-		// n1 family (general purpose)
-		"n1-standard-1":  0.0475,
-		"n1-standard-2":  0.0950,
-		"n1-standard-4":  0.1900,
-		"n1-standard-8":  0.3800,
-		"n1-standard-16": 0.7600,
-		"n1-standard-32": 1.5200,
-		"n1-standard-64": 3.0400,
-
-		// n2 family (newer general purpose)
-		"n2-standard-2":  0.0971,
-		"n2-standard-4":  0.1942,
-		"n2-standard-8":  0.3884,
-		"n2-standard-16": 0.7768,
-		"n2-standard-32": 1.5536,
-		"n2-standard-64": 3.1072,
-
-		// c2 family (compute-optimized)
-		"c2-standard-4":  0.2088,
-		"c2-standard-8":  0.4176,
-		"c2-standard-16": 0.8352,
-		"c2-standard-30": 1.5660,
-		"c2-standard-60": 3.1320,
-
-		// m1 family (memory-optimized)
-		"m1-megamem-96":   10.6740,
-		"m1-ultramem-40":  6.3039,
-		"m1-ultramem-80":  12.6078,
-		"m1-ultramem-160": 25.2156,
+// gcpSharedCoreShapes are e2 shared-core machine types
+var gcpSharedCoreShapes = map[string]InstanceShape{
+	"e2-micro":  {Name: "e2-micro", VCPUs: 2, MemoryGiB: 1},
+	"e2-small":  {Name: "e2-small", VCPUs: 2, MemoryGiB: 2},
+	"e2-medium": {Name: "e2-medium", VCPUs: 2, MemoryGiB: 4},
+}
+
+// gcpMemoryPerVCPU maps machine type classes to GB of memory per vCPU
+var gcpMemoryPerVCPU = map[string]float64{
+	"standard": 4,
+	"highmem":  8,
+	"highcpu":  1,
+	"megamem":  14.9333,
+	"ultramem": 24.025,
+}
+
+// InstanceCatalog returns the shapes of the machine types in the fallback price table
+func (g *GCPProvider) InstanceCatalog() []InstanceShape {
+	catalog := make([]InstanceShape, 0, len(gcpFallbackPrices))
+	for instanceType := range gcpFallbackPrices {
+		if shape, ok := gcpInstanceShape(instanceType); ok {
+			catalog = append(catalog, shape)
+		}
+	}
+	return catalog
+}
+
+// GetComponentRates returns n2 per-vCPU and per-GB rates adjusted for the region
+func (g *GCPProvider) GetComponentRates(ctx context.Context, region string) (ComponentRates, error) {
+	rates := gcpComponentRates["n2"]
+	multiplier := gcpRegionMultiplier(region)
+	return ComponentRates{
+		CPUHourly:       rates.CPUHourly * multiplier,
+		MemoryGiBHourly: rates.MemoryGiBHourly * multiplier,
+		GPUHourly:       0.35 * multiplier, // NVIDIA T4
+	}, nil
+}
+
+// gcpInstanceShape derives vCPUs and memory from a machine type name such as n2-standard-8
+func gcpInstanceShape(instanceType string) (InstanceShape, bool) {
+	if shape, ok := gcpSharedCoreShapes[instanceType]; ok {
+		shape.Architecture = "amd64"
+		return shape, true
+	}
+
+	parts := strings.Split(instanceType, "-")
+	if len(parts) != 3 {
+		return InstanceShape{}, false
 	}
+	family, class := parts[0], parts[1]
 
-	if price, ok := fallbackPrices[instanceType]; ok {
-		// Adjust for region (some regions are more expensive)
-		regionMultiplier := 1.0
-		if strings.Contains(region, "asia") {
-			regionMultiplier = 1.1
-		} else if strings.Contains(region, "australia") {
-			regionMultiplier = 1.2
+	vcpus, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return InstanceShape{}, false
+	}
+	perVCPU, ok := gcpMemoryPerVCPU[class]
+	if !ok {
+		return InstanceShape{}, false
+	}
+	if family == "n1" {
+		// n1 machine types have 3.75 GB per vCPU for standard shapes
+		switch class {
+		case "standard":
+			perVCPU = 3.75
+		case "highmem":
+			perVCPU = 6.5
+		case "highcpu":
+			perVCPU = 0.9
 		}
-		return price * regionMultiplier
+	}
+
+	return InstanceShape{
+		Name:         instanceType,
+		VCPUs:        vcpus,
+		MemoryGiB:    vcpus * perVCPU,
+		Architecture: GCPArchitecture(instanceType),
+	}, true
+}
+
+// gcpRegionMultiplier adjusts us-central1 prices for more expensive regions
+func gcpRegionMultiplier(region string) float64 {
+	if strings.Contains(region, "asia") {
+		return 1.1
+	} else if strings.Contains(region, "australia") {
+		return 1.2
+	}
+	return 1.0
+}
+
+// gcpComponentRates are on-demand us-central1 prices per vCPU and per GB of memory,
+// used to split a machine's price between its CPU and memory
+var gcpComponentRates = map[string]ComponentRates{
+	"n1":  {CPUHourly: 0.031611, MemoryGiBHourly: 0.004237},
+	"n2":  {CPUHourly: 0.031611, MemoryGiBHourly: 0.004237},
+	"n2d": {CPUHourly: 0.027502, MemoryGiBHourly: 0.003686},
+	"e2":  {CPUHourly: 0.021811, MemoryGiBHourly: 0.002923},
+	"c2":  {CPUHourly: 0.03398, MemoryGiBHourly: 0.00455},
+	"c2d": {CPUHourly: 0.029563, MemoryGiBHourly: 0.003959},
+	"t2d": {CPUHourly: 0.027502, MemoryGiBHourly: 0.003686},
+	"t2a": {CPUHourly: 0.0231, MemoryGiBHourly: 0.0039},
+	"m1":  {CPUHourly: 0.0348, MemoryGiBHourly: 0.0051},
+}
+
+// GCPComponentRates returns the per-vCPU and per-GB memory rates for a machine family
+func GCPComponentRates(family string) (ComponentRates, bool) {
+	rates, ok := gcpComponentRates[family]
+	return rates, ok
+}
+
+// getFallbackPrice returns fallback pricing for common GCP instance types
+func (g *GCPProvider) getFallbackPrice(instanceType, region string) float64 {
+	// Format: n1-standard-1, n2-standard-4, e2-medium, etc.
+
+	if price, ok := gcpFallbackPrices[instanceType]; ok {
+		return price * gcpRegionMultiplier(region)
 	}
 
 	// Estimate based on instance family
@@ -0,0 +1,82 @@
+package pricing
+
+import (
+	"strings"
+)
+
+// Sustained-use discount rates, as the fraction of the base price charged for
+// usage in each quarter of the billing month
+var (
+	gcpSUDTiersN1 = []float64{1.0, 0.8, 0.6, 0.4}
+	gcpSUDTiersN2 = []float64{1.0, 0.8678, 0.7356, 0.6034}
+)
+
+// gcpSUDTiers maps machine families to their sustained-use discount tiers.
+// Families not listed (e2, t2d, t2a, a2, c3, ...) are not eligible.
+var gcpSUDTiers = map[string][]float64{
+	"n1":  gcpSUDTiersN1,
+	"n2":  gcpSUDTiersN2,
+	"n2d": gcpSUDTiersN2,
+	"c2":  gcpSUDTiersN2,
+	"c2d": gcpSUDTiersN2,
+	"m1":  gcpSUDTiersN2,
+	"m2":  gcpSUDTiersN2,
+}
+
+// gcpCUDRates maps machine families to resource-based commitment discounts by term
+var gcpCUDRates = map[string]map[string]float64{
+	"n1":  {"1y": 0.37, "3y": 0.55},
+	"n2":  {"1y": 0.37, "3y": 0.55},
+	"n2d": {"1y": 0.37, "3y": 0.55},
+	"e2":  {"1y": 0.37, "3y": 0.55},
+	"c2":  {"1y": 0.37, "3y": 0.55},
+	"c2d": {"1y": 0.37, "3y": 0.55},
+	"c3":  {"1y": 0.37, "3y": 0.55},
+	"t2d": {"1y": 0.37, "3y": 0.55},
+	"m1":  {"1y": 0.41, "3y": 0.60},
+	"m2":  {"1y": 0.41, "3y": 0.60},
+}
+
+// GCPMachineFamily returns the machine family of a GCE machine type (n2-standard-4 -> n2)
+func GCPMachineFamily(instanceType string) string {
+	return strings.SplitN(instanceType, "-", 2)[0]
+}
+
+// GCPSustainedUseMultiplier returns the average fraction of the base price charged for
+// an instance that has run for monthFraction (0-1) of the billing month. Families that
+// are not eligible for sustained-use discounts always return 1.
+func GCPSustainedUseMultiplier(family string, monthFraction float64) float64 {
+	tiers, ok := gcpSUDTiers[family]
+	if !ok || monthFraction <= 0 {
+		return 1.0
+	}
+	if monthFraction > 1 {
+		monthFraction = 1
+	}
+
+	// Each tier covers a quarter of the month at its incremental rate
+	tierSize := 1.0 / float64(len(tiers))
+	var charged, remaining float64 = 0, monthFraction
+	for _, rate := range tiers {
+		usage := remaining
+		if usage > tierSize {
+			usage = tierSize
+		}
+		charged += usage * rate
+		remaining -= usage
+		if remaining <= 0 {
+			break
+		}
+	}
+
+	return charged / monthFraction
+}
+
+// GCPCommittedUseDiscount returns the discount rate (0-1) of a resource-based
+// commitment for a machine family and term (1y or 3y)
+func GCPCommittedUseDiscount(family, term string) float64 {
+	if rates, ok := gcpCUDRates[family]; ok {
+		return rates[term]
+	}
+	return 0
+}
@@ -0,0 +1,144 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultEURToUSD is used to convert Hetzner's EUR prices when no rate is configured
+const defaultEURToUSD = 1.08
+
+// HetznerProvider implements the Provider interface for Hetzner Cloud
+type HetznerProvider struct {
+	serverTypes map[string]hetznerServerType
+	eurToUSD    float64
+	logger      *logrus.Logger
+}
+
+// hetznerServerTypesResponse is the format of the Hetzner Cloud /v1/server_types price list
+type hetznerServerTypesResponse struct {
+	ServerTypes []hetznerServerType `json:"server_types"`
+}
+
+type hetznerServerType struct {
+	Name         string         `json:"name"`
+	Cores        int            `json:"cores"`
+	Memory       float64        `json:"memory"` // GB
+	CPUType      string         `json:"cpu_type"`
+	Architecture string         `json:"architecture"`
+	Prices       []hetznerPrice `json:"prices"`
+}
+
+type hetznerPrice struct {
+	Location     string            `json:"location"`
+	PriceHourly  hetznerPriceValue `json:"price_hourly"`
+	PriceMonthly hetznerPriceValue `json:"price_monthly"`
+}
+
+// hetznerPriceValue holds a price in EUR, as decimal strings
+type hetznerPriceValue struct {
+	Net   string `json:"net"`
+	Gross string `json:"gross"`
+}
+
+// NewHetznerProvider creates a new Hetzner Cloud pricing provider from a /v1/server_types
+// price list. An empty path uses the bundled price list. Prices are listed in EUR and
+// converted with eurToUSD; zero uses a default rate.
+func NewHetznerProvider(priceListPath string, eurToUSD float64) (*HetznerProvider, error) {
+	data, err := readPriceList(priceListPath, "hetzner")
+	if err != nil {
+		return nil, err
+	}
+
+	var priceList hetznerServerTypesResponse
+	if err := json.Unmarshal(data, &priceList); err != nil {
+		return nil, fmt.Errorf("failed to parse Hetzner price list: %w", err)
+	}
+
+	serverTypes := make(map[string]hetznerServerType, len(priceList.ServerTypes))
+	for _, serverType := range priceList.ServerTypes {
+		serverTypes[serverType.Name] = serverType
+	}
+
+	if eurToUSD <= 0 {
+		eurToUSD = defaultEURToUSD
+	}
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+
+	return &HetznerProvider{
+		serverTypes: serverTypes,
+		eurToUSD:    eurToUSD,
+		logger:      logger,
+	}, nil
+}
+
+// GetInstancePrice returns the hourly price for a Hetzner Cloud server type
+func (h *HetznerProvider) GetInstancePrice(ctx context.Context, instanceType, region, az string) (float64, error) {
+	serverType, ok := h.serverTypes[strings.ToLower(instanceType)]
+	if !ok {
+		return 0, fmt.Errorf("no Hetzner price for server type %s", instanceType)
+	}
+	if len(serverType.Prices) == 0 {
+		return 0, fmt.Errorf("no Hetzner locations for server type %s", instanceType)
+	}
+
+	// Prices are per location (fsn1, nbg1, hel1, ash, ...). The hcloud cloud
+	// controller sets the zone to <location>-dc<N> and the region to the network zone.
+	location := strings.Split(az, "-dc")[0]
+	price := serverType.Prices[0]
+	for _, p := range serverType.Prices {
+		if p.Location == location || p.Location == region {
+			price = p
+			break
+		}
+	}
+
+	net, err := strconv.ParseFloat(price.PriceHourly.Net, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse Hetzner price for %s: %w", instanceType, err)
+	}
+
+	return net * h.eurToUSD, nil
+}
+
+// GetSpotPrice returns the on-demand price, as Hetzner has no spot servers
+func (h *HetznerProvider) GetSpotPrice(ctx context.Context, instanceType, region, az string) (float64, error) {
+	return h.GetInstancePrice(ctx, instanceType, region, az)
+}
+
+// InstanceCatalog returns the shapes of the server types in the price list
+func (h *HetznerProvider) InstanceCatalog() []InstanceShape {
+	catalog := make([]InstanceShape, 0, len(h.serverTypes))
+	for _, serverType := range h.serverTypes {
+		arch := "amd64"
+		if serverType.Architecture == "arm" {
+			arch = "arm64"
+		}
+		catalog = append(catalog, InstanceShape{
+			Name:         serverType.Name,
+			VCPUs:        float64(serverType.Cores),
+			MemoryGiB:    serverType.Memory,
+			Architecture: arch,
+		})
+	}
+	return catalog
+}
+
+// GetStoragePrice returns the price per GB/month for Hetzner Cloud volumes
+func (h *HetznerProvider) GetStoragePrice(ctx context.Context, storageType, region string) (float64, error) {
+	// Volumes: €0.044 per GB/month
+	return 0.044 * h.eurToUSD, nil
+}
+
+// GetNetworkPrice returns the price per GB for network egress
+func (h *HetznerProvider) GetNetworkPrice(ctx context.Context, region, destination string) (float64, error) {
+	// Traffic beyond the included allowance: €1.00 per TB
+	return 1.0 / 1000 * h.eurToUSD, nil
+}
@@ -0,0 +1,79 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHetznerInstancePrice(t *testing.T) {
+	provider, err := NewHetznerProvider("", 1.1)
+	if err != nil {
+		t.Fatalf("NewHetznerProvider: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		instanceType string
+		region       string
+		az           string
+		want         float64
+		wantErr      bool
+	}{
+		{name: "location from zone", instanceType: "cpx21", region: "us-east", az: "ash-dc1", want: 0.0145 * 1.1},
+		{name: "location as region", instanceType: "cpx21", region: "fsn1", want: 0.0121 * 1.1},
+		{name: "upper case type", instanceType: "CAX21", region: "eu-central", az: "nbg1-dc3", want: 0.0104 * 1.1},
+		{name: "unknown location uses first price", instanceType: "cx22", region: "eu-central", az: "xyz1-dc1", want: 0.006 * 1.1},
+		{name: "unknown type", instanceType: "cx99", region: "eu-central", az: "fsn1-dc14", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.GetInstancePrice(context.Background(), tt.instanceType, tt.region, tt.az)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GetInstancePrice(%q) = %v, want error", tt.instanceType, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetInstancePrice(%q): %v", tt.instanceType, err)
+			}
+			if !approxEqual(got, tt.want) {
+				t.Errorf("GetInstancePrice(%q, %q, %q) = %v, want %v", tt.instanceType, tt.region, tt.az, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHetznerDefaultExchangeRate(t *testing.T) {
+	provider, err := NewHetznerProvider("", 0)
+	if err != nil {
+		t.Fatalf("NewHetznerProvider: %v", err)
+	}
+
+	got, err := provider.GetInstancePrice(context.Background(), "cx22", "fsn1", "")
+	if err != nil {
+		t.Fatalf("GetInstancePrice: %v", err)
+	}
+	if want := 0.006 * defaultEURToUSD; !approxEqual(got, want) {
+		t.Errorf("GetInstancePrice = %v, want %v", got, want)
+	}
+}
+
+func TestHetznerInstanceCatalog(t *testing.T) {
+	provider, err := NewHetznerProvider("", 0)
+	if err != nil {
+		t.Fatalf("NewHetznerProvider: %v", err)
+	}
+
+	for _, shape := range provider.InstanceCatalog() {
+		if shape.Name != "cax31" {
+			continue
+		}
+		if shape.VCPUs != 8 || shape.MemoryGiB != 16 || shape.Architecture != "arm64" {
+			t.Errorf("catalog shape %s = %+v, want 8 vCPUs, 16 GiB, arm64", shape.Name, shape)
+		}
+		return
+	}
+	t.Error("catalog has no cax31 shape")
+}
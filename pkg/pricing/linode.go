@@ -0,0 +1,117 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LinodeProvider implements the Provider interface for Akamai Linode
+type LinodeProvider struct {
+	types  map[string]linodeType
+	logger *logrus.Logger
+}
+
+// linodeTypesResponse is the format of the Linode /v4/linode/types price list
+type linodeTypesResponse struct {
+	Data []linodeType `json:"data"`
+}
+
+type linodeType struct {
+	ID           string              `json:"id"`
+	Label        string              `json:"label"`
+	Memory       int64               `json:"memory"` // MiB
+	VCPUs        int                 `json:"vcpus"`
+	GPUs         int                 `json:"gpus"`
+	Class        string              `json:"class"`
+	Price        linodePrice         `json:"price"`
+	RegionPrices []linodeRegionPrice `json:"region_prices"`
+}
+
+type linodePrice struct {
+	Hourly  float64 `json:"hourly"`
+	Monthly float64 `json:"monthly"`
+}
+
+type linodeRegionPrice struct {
+	ID      string  `json:"id"`
+	Hourly  float64 `json:"hourly"`
+	Monthly float64 `json:"monthly"`
+}
+
+// NewLinodeProvider creates a new Linode pricing provider from a /v4/linode/types
+// price list. An empty path uses the bundled price list.
+func NewLinodeProvider(priceListPath string) (*LinodeProvider, error) {
+	data, err := readPriceList(priceListPath, "linode")
+	if err != nil {
+		return nil, err
+	}
+
+	var priceList linodeTypesResponse
+	if err := json.Unmarshal(data, &priceList); err != nil {
+		return nil, fmt.Errorf("failed to parse Linode price list: %w", err)
+	}
+
+	types := make(map[string]linodeType, len(priceList.Data))
+	for _, t := range priceList.Data {
+		types[t.ID] = t
+	}
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+
+	return &LinodeProvider{
+		types:  types,
+		logger: logger,
+	}, nil
+}
+
+// GetInstancePrice returns the hourly price for a Linode plan
+func (l *LinodeProvider) GetInstancePrice(ctx context.Context, instanceType, region, az string) (float64, error) {
+	t, ok := l.types[instanceType]
+	if !ok {
+		return 0, fmt.Errorf("no Linode price for type %s", instanceType)
+	}
+
+	// Some regions are priced differently from the base price
+	for _, regionPrice := range t.RegionPrices {
+		if regionPrice.ID == region {
+			return regionPrice.Hourly, nil
+		}
+	}
+
+	return t.Price.Hourly, nil
+}
+
+// GetSpotPrice returns the on-demand price, as Linode has no spot instances
+func (l *LinodeProvider) GetSpotPrice(ctx context.Context, instanceType, region, az string) (float64, error) {
+	return l.GetInstancePrice(ctx, instanceType, region, az)
+}
+
+// InstanceCatalog returns the shapes of the Linode plans in the price list
+func (l *LinodeProvider) InstanceCatalog() []InstanceShape {
+	catalog := make([]InstanceShape, 0, len(l.types))
+	for _, t := range l.types {
+		catalog = append(catalog, InstanceShape{
+			Name:         t.ID,
+			VCPUs:        float64(t.VCPUs),
+			MemoryGiB:    float64(t.Memory) / 1024,
+			GPUs:         t.GPUs,
+			Architecture: "amd64",
+		})
+	}
+	return catalog
+}
+
+// GetStoragePrice returns the price per GB/month for block storage volumes
+func (l *LinodeProvider) GetStoragePrice(ctx context.Context, storageType, region string) (float64, error) {
+	return 0.10, nil
+}
+
+// GetNetworkPrice returns the price per GB for network egress
+func (l *LinodeProvider) GetNetworkPrice(ctx context.Context, region, destination string) (float64, error) {
+	// Outbound transfer beyond the pooled allowance: $0.005/GB
+	return 0.005, nil
+}
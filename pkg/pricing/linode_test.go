@@ -0,0 +1,62 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLinodeInstancePrice(t *testing.T) {
+	provider, err := NewLinodeProvider("")
+	if err != nil {
+		t.Fatalf("NewLinodeProvider: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		instanceType string
+		region       string
+		want         float64
+		wantErr      bool
+	}{
+		{name: "base price", instanceType: "g6-standard-2", region: "us-east", want: 0.036},
+		{name: "region price", instanceType: "g6-standard-2", region: "br-gru", want: 0.0504},
+		{name: "dedicated plan", instanceType: "g6-dedicated-4", region: "eu-west", want: 0.108},
+		{name: "unknown type", instanceType: "g6-standard-64", region: "us-east", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.GetInstancePrice(context.Background(), tt.instanceType, tt.region, "")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GetInstancePrice(%q) = %v, want error", tt.instanceType, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetInstancePrice(%q): %v", tt.instanceType, err)
+			}
+			if !approxEqual(got, tt.want) {
+				t.Errorf("GetInstancePrice(%q, %q) = %v, want %v", tt.instanceType, tt.region, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinodeInstanceCatalog(t *testing.T) {
+	provider, err := NewLinodeProvider("")
+	if err != nil {
+		t.Fatalf("NewLinodeProvider: %v", err)
+	}
+
+	for _, shape := range provider.InstanceCatalog() {
+		if shape.Name != "g1-gpu-rtx6000-1" {
+			continue
+		}
+		if shape.VCPUs != 8 || shape.MemoryGiB != 32 || shape.GPUs != 1 {
+			t.Errorf("catalog shape %s = %+v, want 8 vCPUs, 32 GiB, 1 GPU", shape.Name, shape)
+		}
+		return
+	}
+	t.Error("catalog has no g1-gpu-rtx6000-1 shape")
+}
@@ -0,0 +1,216 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Default size used for flexible shapes when the instance size is unknown
+const (
+	defaultFlexOCPUs    = 1
+	defaultFlexMemoryGB = 16
+)
+
+// OracleProvider implements the Provider interface for Oracle Cloud Infrastructure
+type OracleProvider struct {
+	prices map[string]float64 // part display name -> USD pay-as-you-go price
+	logger *logrus.Logger
+}
+
+// oracleProductsResponse is the format of the OCI cost estimator products price list
+type oracleProductsResponse struct {
+	Items []oracleProduct `json:"items"`
+}
+
+type oracleProduct struct {
+	PartNumber                string                       `json:"partNumber"`
+	DisplayName               string                       `json:"displayName"`
+	MetricName                string                       `json:"metricName"`
+	ServiceCategory           string                       `json:"serviceCategory"`
+	CurrencyCodeLocalizations []oracleCurrencyLocalization `json:"currencyCodeLocalizations"`
+}
+
+type oracleCurrencyLocalization struct {
+	CurrencyCode string        `json:"currencyCode"`
+	Prices       []oraclePrice `json:"prices"`
+}
+
+type oraclePrice struct {
+	Model string  `json:"model"`
+	Value float64 `json:"value"`
+}
+
+// oracleShape describes how an OCI compute shape is billed
+type oracleShape struct {
+	ocpuPart     string  // per-OCPU price list part
+	memoryPart   string  // per-GB memory part, empty when memory is included
+	vcpusPerOCPU float64 // x86 OCPUs are two vCPUs, Ampere OCPUs are one
+	flexible     bool
+}
+
+// oracleShapes maps OCI shape names to their price list parts
+var oracleShapes = map[string]oracleShape{
+	"VM.Standard.E4.Flex": {ocpuPart: "Compute - Standard - E4 - OCPU", memoryPart: "Compute - Standard - E4 - Memory", vcpusPerOCPU: 2, flexible: true},
+	"VM.Standard.E5.Flex": {ocpuPart: "Compute - Standard - E5 - OCPU", memoryPart: "Compute - Standard - E5 - Memory", vcpusPerOCPU: 2, flexible: true},
+	"VM.Standard3.Flex":   {ocpuPart: "Compute - Standard - X9 - OCPU", memoryPart: "Compute - Standard - X9 - Memory", vcpusPerOCPU: 2, flexible: true},
+	"VM.Standard.A1.Flex": {ocpuPart: "Compute - Ampere A1 - OCPU", memoryPart: "Compute - Ampere A1 - Memory", vcpusPerOCPU: 1, flexible: true},
+	"VM.Standard2":        {ocpuPart: "Compute - Standard - X7", vcpusPerOCPU: 2},
+}
+
+// NewOracleProvider creates a new OCI pricing provider from the OCI cost estimator
+// products price list. An empty path uses the bundled price list.
+func NewOracleProvider(priceListPath string) (*OracleProvider, error) {
+	data, err := readPriceList(priceListPath, "oracle")
+	if err != nil {
+		return nil, err
+	}
+
+	var priceList oracleProductsResponse
+	if err := json.Unmarshal(data, &priceList); err != nil {
+		return nil, fmt.Errorf("failed to parse Oracle price list: %w", err)
+	}
+
+	prices := make(map[string]float64, len(priceList.Items))
+	for _, item := range priceList.Items {
+		for _, localization := range item.CurrencyCodeLocalizations {
+			if localization.CurrencyCode != "USD" {
+				continue
+			}
+			for _, price := range localization.Prices {
+				if price.Model == "PAY_AS_YOU_GO" {
+					prices[item.DisplayName] = price.Value
+				}
+			}
+		}
+	}
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.InfoLevel)
+
+	return &OracleProvider{
+		prices: prices,
+		logger: logger,
+	}, nil
+}
+
+// GetInstancePrice returns the hourly price for an OCI compute shape. Flexible
+// shapes are priced at the default 1 OCPU / 16 GB configuration.
+func (o *OracleProvider) GetInstancePrice(ctx context.Context, instanceType, region, az string) (float64, error) {
+	shape, ocpus, ok := o.lookupShape(instanceType)
+	if !ok {
+		return 0, fmt.Errorf("no Oracle price for shape %s", instanceType)
+	}
+
+	if shape.flexible {
+		return o.shapePrice(shape, defaultFlexOCPUs, defaultFlexMemoryGB)
+	}
+	return o.shapePrice(shape, ocpus, 0)
+}
+
+// GetSpotPrice returns the preemptible instance price
+func (o *OracleProvider) GetSpotPrice(ctx context.Context, instanceType, region, az string) (float64, error) {
+	// Preemptible instances are billed at 50% of on-demand
+	onDemand, err := o.GetInstancePrice(ctx, instanceType, region, az)
+	if err != nil {
+		return 0, err
+	}
+	return onDemand * 0.5, nil
+}
+
+// IsFlexible reports whether the shape is billed by its configured OCPUs and memory
+func (o *OracleProvider) IsFlexible(instanceType string) bool {
+	shape, _, ok := o.lookupShape(instanceType)
+	return ok && shape.flexible
+}
+
+// GetFlexiblePrice returns the hourly price of a flexible shape with the given size
+func (o *OracleProvider) GetFlexiblePrice(ctx context.Context, instanceType, region string, vcpus, memoryGiB float64, spot bool) (float64, error) {
+	shape, _, ok := o.lookupShape(instanceType)
+	if !ok || !shape.flexible {
+		return 0, fmt.Errorf("%s is not a flexible Oracle shape", instanceType)
+	}
+
+	price, err := o.shapePrice(shape, vcpus/shape.vcpusPerOCPU, memoryGiB)
+	if err != nil {
+		return 0, err
+	}
+	if spot {
+		price *= 0.5
+	}
+	return price, nil
+}
+
+// GetComponentRates returns per-vCPU and per-GB rates from the E4 flexible shape parts
+func (o *OracleProvider) GetComponentRates(ctx context.Context, region string) (ComponentRates, error) {
+	shape := oracleShapes["VM.Standard.E4.Flex"]
+	ocpuPrice, ok := o.prices[shape.ocpuPart]
+	if !ok {
+		return ComponentRates{}, fmt.Errorf("no Oracle price list entry for %s", shape.ocpuPart)
+	}
+	return ComponentRates{
+		CPUHourly:       ocpuPrice / shape.vcpusPerOCPU,
+		MemoryGiBHourly: o.prices[shape.memoryPart],
+	}, nil
+}
+
+// GetStoragePrice returns the price per GB/month for block volumes
+func (o *OracleProvider) GetStoragePrice(ctx context.Context, storageType, region string) (float64, error) {
+	storage := o.prices["Storage - Block Volume - Storage"]
+	// Balanced performance is 10 VPUs per GB
+	performance := o.prices["Storage - Block Volume - Performance Units"] * 10
+	if storage == 0 {
+		return 0.0255, nil
+	}
+	return storage + performance, nil
+}
+
+// GetNetworkPrice returns the price per GB for network egress
+func (o *OracleProvider) GetNetworkPrice(ctx context.Context, region, destination string) (float64, error) {
+	// The first 10 TB of egress each month is free; this is the price beyond it
+	if price, ok := o.prices["Outbound Data Transfer - Originating in North America, Europe, and UK"]; ok {
+		return price, nil
+	}
+	return 0.0085, nil
+}
+
+// lookupShape resolves a shape name to its billing description. Fixed shapes such
+// as VM.Standard2.4 also return their OCPU count.
+func (o *OracleProvider) lookupShape(instanceType string) (oracleShape, float64, bool) {
+	if shape, ok := oracleShapes[instanceType]; ok && shape.flexible {
+		return shape, 0, true
+	}
+
+	// Fixed shapes: <series>.<ocpus>
+	idx := strings.LastIndex(instanceType, ".")
+	if idx < 0 {
+		return oracleShape{}, 0, false
+	}
+	shape, ok := oracleShapes[instanceType[:idx]]
+	if !ok || shape.flexible {
+		return oracleShape{}, 0, false
+	}
+	ocpus, err := strconv.ParseFloat(instanceType[idx+1:], 64)
+	if err != nil {
+		return oracleShape{}, 0, false
+	}
+	return shape, ocpus, true
+}
+
+// shapePrice sums the OCPU and memory parts for a shape
+func (o *OracleProvider) shapePrice(shape oracleShape, ocpus, memoryGB float64) (float64, error) {
+	ocpuPrice, ok := o.prices[shape.ocpuPart]
+	if !ok {
+		return 0, fmt.Errorf("no Oracle price list entry for %s", shape.ocpuPart)
+	}
+
+	price := ocpuPrice * ocpus
+	if shape.memoryPart != "" {
+		price += o.prices[shape.memoryPart] * memoryGB
+	}
+	return price, nil
+}
@@ -0,0 +1,116 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOracleInstancePrice(t *testing.T) {
+	provider, err := NewOracleProvider("")
+	if err != nil {
+		t.Fatalf("NewOracleProvider: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		instanceType string
+		want         float64
+		wantErr      bool
+	}{
+		{name: "flexible shape at default size", instanceType: "VM.Standard.E4.Flex", want: 0.025*1 + 0.0015*16},
+		{name: "ampere flexible shape", instanceType: "VM.Standard.A1.Flex", want: 0.01*1 + 0.0015*16},
+		{name: "fixed shape by OCPU count", instanceType: "VM.Standard2.4", want: 0.0638 * 4},
+		{name: "unknown shape", instanceType: "VM.Standard9.Flex", wantErr: true},
+		{name: "fixed series without size", instanceType: "VM.Standard2", wantErr: true},
+		{name: "fixed shape with invalid size", instanceType: "VM.Standard2.x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.GetInstancePrice(context.Background(), tt.instanceType, "us-ashburn-1", "")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GetInstancePrice(%q) = %v, want error", tt.instanceType, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetInstancePrice(%q): %v", tt.instanceType, err)
+			}
+			if !approxEqual(got, tt.want) {
+				t.Errorf("GetInstancePrice(%q) = %v, want %v", tt.instanceType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOracleFlexiblePrice(t *testing.T) {
+	provider, err := NewOracleProvider("")
+	if err != nil {
+		t.Fatalf("NewOracleProvider: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		instanceType string
+		vcpus        float64
+		memoryGiB    float64
+		spot         bool
+		want         float64
+		wantErr      bool
+	}{
+		// x86 OCPUs are two vCPUs
+		{name: "E4 8 vCPUs 64 GB", instanceType: "VM.Standard.E4.Flex", vcpus: 8, memoryGiB: 64, want: 0.025*4 + 0.0015*64},
+		{name: "E5 preemptible", instanceType: "VM.Standard.E5.Flex", vcpus: 4, memoryGiB: 32, spot: true, want: (0.03*2 + 0.002*32) * 0.5},
+		// Ampere OCPUs are one vCPU
+		{name: "A1 4 vCPUs 24 GB", instanceType: "VM.Standard.A1.Flex", vcpus: 4, memoryGiB: 24, want: 0.01*4 + 0.0015*24},
+		{name: "fixed shape", instanceType: "VM.Standard2.4", vcpus: 8, memoryGiB: 60, wantErr: true},
+		{name: "unknown shape", instanceType: "VM.Unknown.Flex", vcpus: 2, memoryGiB: 8, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := provider.IsFlexible(tt.instanceType); got == tt.wantErr {
+				t.Errorf("IsFlexible(%q) = %v", tt.instanceType, got)
+			}
+
+			got, err := provider.GetFlexiblePrice(context.Background(), tt.instanceType, "us-ashburn-1", tt.vcpus, tt.memoryGiB, tt.spot)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GetFlexiblePrice(%q) = %v, want error", tt.instanceType, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetFlexiblePrice(%q): %v", tt.instanceType, err)
+			}
+			if !approxEqual(got, tt.want) {
+				t.Errorf("GetFlexiblePrice(%q) = %v, want %v", tt.instanceType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOracleComponentAndStoragePrices(t *testing.T) {
+	provider, err := NewOracleProvider("")
+	if err != nil {
+		t.Fatalf("NewOracleProvider: %v", err)
+	}
+	ctx := context.Background()
+
+	rates, err := provider.GetComponentRates(ctx, "us-ashburn-1")
+	if err != nil {
+		t.Fatalf("GetComponentRates: %v", err)
+	}
+	if !approxEqual(rates.CPUHourly, 0.025/2) || !approxEqual(rates.MemoryGiBHourly, 0.0015) {
+		t.Errorf("GetComponentRates = %+v, want E4 OCPU per vCPU and memory per GB", rates)
+	}
+
+	storage, err := provider.GetStoragePrice(ctx, "oci-bv", "us-ashburn-1")
+	if err != nil {
+		t.Fatalf("GetStoragePrice: %v", err)
+	}
+	if want := 0.0255 + 0.0017*10; !approxEqual(storage, want) {
+		t.Errorf("GetStoragePrice = %v, want %v", storage, want)
+	}
+}
@@ -0,0 +1,31 @@
+package pricing
+
+import (
+	"embed"
+	"fmt"
+	"os"
+)
+
+// Snapshots of each vendor's public price list, in the vendor's own format.
+// They are used when no price list file is configured for a provider.
+//
+//go:embed pricelists/*.json
+var bundledPriceLists embed.FS
+
+// readPriceList reads a vendor price list from path, or the bundled snapshot
+// for the vendor when path is empty
+func readPriceList(path, vendor string) ([]byte, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s price list: %w", vendor, err)
+		}
+		return data, nil
+	}
+
+	data, err := bundledPriceLists.ReadFile("pricelists/" + vendor + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("no bundled %s price list: %w", vendor, err)
+	}
+	return data, nil
+}
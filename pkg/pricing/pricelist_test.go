@@ -0,0 +1,53 @@
+package pricing
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// approxEqual reports whether two prices are equal to within rounding
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestReadPriceList(t *testing.T) {
+	for _, vendor := range []string{"oracle", "digitalocean", "linode", "hetzner"} {
+		data, err := readPriceList("", vendor)
+		if err != nil {
+			t.Errorf("readPriceList(%q) bundled: %v", vendor, err)
+			continue
+		}
+		if len(data) == 0 {
+			t.Errorf("readPriceList(%q) bundled: empty price list", vendor)
+		}
+	}
+
+	if _, err := readPriceList("", "unknown"); err == nil {
+		t.Error("readPriceList(unknown vendor) returned no error")
+	}
+	if _, err := readPriceList(filepath.Join(t.TempDir(), "missing.json"), "oracle"); err == nil {
+		t.Error("readPriceList(missing file) returned no error")
+	}
+}
+
+func TestNewProvidersRejectInvalidPriceLists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalid.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewOracleProvider(path); err == nil {
+		t.Error("NewOracleProvider accepted an invalid price list")
+	}
+	if _, err := NewDigitalOceanProvider(path); err == nil {
+		t.Error("NewDigitalOceanProvider accepted an invalid price list")
+	}
+	if _, err := NewLinodeProvider(path); err == nil {
+		t.Error("NewLinodeProvider accepted an invalid price list")
+	}
+	if _, err := NewHetznerProvider(path, 0); err == nil {
+		t.Error("NewHetznerProvider accepted an invalid price list")
+	}
+}
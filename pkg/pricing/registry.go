@@ -0,0 +1,56 @@
+package pricing
+
+import (
+	"sort"
+)
+
+// Registry holds the pricing providers available to the agent, keyed by provider name.
+// Nodes and volumes are priced with the provider they run on; anything that cannot be
+// matched to a registered provider is priced with the default provider.
+type Registry struct {
+	caches          map[string]*PricingCache
+	defaultProvider string
+}
+
+// NewRegistry creates an empty provider registry with the given default provider name
+func NewRegistry(defaultProvider string) *Registry {
+	return &Registry{
+		caches:          make(map[string]*PricingCache),
+		defaultProvider: defaultProvider,
+	}
+}
+
+// Register wraps a provider with caching and adds it to the registry
+func (r *Registry) Register(name string, provider Provider) {
+	r.caches[name] = NewPricingCache(provider)
+}
+
+// Get returns the pricing cache for a provider, if registered
+func (r *Registry) Get(name string) (*PricingCache, bool) {
+	cache, ok := r.caches[name]
+	return cache, ok
+}
+
+// Resolve returns the provider name and pricing cache to use for the given provider.
+// Unknown or unregistered providers resolve to the default provider.
+func (r *Registry) Resolve(name string) (string, *PricingCache) {
+	if cache, ok := r.caches[name]; ok {
+		return name, cache
+	}
+	return r.defaultProvider, r.caches[r.defaultProvider]
+}
+
+// DefaultProvider returns the name of the default provider
+func (r *Registry) DefaultProvider() string {
+	return r.defaultProvider
+}
+
+// Providers returns the names of all registered providers
+func (r *Registry) Providers() []string {
+	names := make([]string, 0, len(r.caches))
+	for name := range r.caches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
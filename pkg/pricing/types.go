@@ -20,6 +20,23 @@ type Provider interface {
 	GetNetworkPrice(ctx context.Context, region, destination string) (float64, error)
 }
 
+// FlexiblePricer is implemented by providers whose instance price depends on the
+// CPU and memory configured for the instance rather than on the instance type alone
+type FlexiblePricer interface {
+	// IsFlexible reports whether the instance type is priced by its configured size
+	IsFlexible(instanceType string) bool
+
+	// GetFlexiblePrice returns the hourly price of a flexible instance with the given size
+	GetFlexiblePrice(ctx context.Context, instanceType, region string, vcpus, memoryGiB float64, spot bool) (float64, error)
+}
+
+// ComponentRates are the hourly prices of individual instance resources
+type ComponentRates struct {
+	CPUHourly       float64 // per vCPU
+	MemoryGiBHourly float64 // per GiB of memory
+	GPUHourly       float64 // per GPU
+}
+
 // PricingCache wraps a provider with caching
 type PricingCache struct {
 	provider Provider